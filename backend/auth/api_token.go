@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// APITokenPrefix kennzeichnet persönliche API-Tokens und unterscheidet sie von JWTs
+const APITokenPrefix = "mc_"
+
+// Scopes für persönliche API-Tokens
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+)
+
+// APITokenResolver löst einen persönlichen API-Token in die Claims seines Benutzers auf
+type APITokenResolver interface {
+	ResolveAPIToken(token string) (*Claims, error)
+}
+
+// UserResolver löst wie APITokenResolver API-Tokens auf und liefert außerdem die aktuelle Rolle eines Benutzers
+// aus seinem Konto, damit geänderte Rollen und gelöschte Konten auch für bereits ausgestellte JWTs sofort gelten
+type UserResolver interface {
+	APITokenResolver
+	// CurrentRole liefert einen Fehler, wenn das Konto nicht mehr existiert
+	CurrentRole(userID uint) (string, error)
+}
+
+// GenerateAPIToken erzeugt einen zufälligen API-Token mit APITokenPrefix
+func GenerateAPIToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return APITokenPrefix + hex.EncodeToString(buf), nil
+}
+
+// HashAPIToken liefert den SHA-256-Hash eines API-Tokens, wie er in der Datenbank gespeichert wird
+func HashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsAPIToken prüft, ob token ein persönlicher API-Token ist
+func IsAPIToken(token string) bool {
+	return strings.HasPrefix(token, APITokenPrefix)
+}
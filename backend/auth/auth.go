@@ -2,16 +2,41 @@ package auth
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// ClaimsContextKey ist der Schlüssel, unter dem die Claims im Gin-Kontext abgelegt werden
+const ClaimsContextKey = "auth_claims"
+
+// Rollen der Benutzer, aufsteigend nach Berechtigungen
+const (
+	RoleViewer = "viewer"
+	RoleEditor = "editor"
+	RoleAdmin  = "admin"
+)
+
+var roleRanks = map[string]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+var errNoToken = errors.New("authorization header is required")
+
 // Claims represents the JWT claims structure
 type Claims struct {
 	UserID   uint   `json:"user_id"`
 	Username string `json:"username"`
+	Role     string `json:"role,omitempty"`
+	Type     string `json:"type,omitempty"`
+	// Scope ist nur bei persönlichen API-Tokens gesetzt (read oder write)
+	Scope string `json:"scope,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -33,6 +58,9 @@ func GenerateToken(secret string, userID uint, username string, expiration time.
 // ValidateToken validates a JWT token and returns its claims
 func ValidateToken(secret string, tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
 		return []byte(secret), nil
 	})
 	if err != nil {
@@ -46,19 +74,119 @@ func ValidateToken(secret string, tokenString string) (*Claims, error) {
 	return nil, errors.New("invalid token")
 }
 
-// AuthMiddleware creates a middleware function that validates JWT tokens
-func AuthMiddleware(secret string) func(*http.Request) (*Claims, error) {
-	return func(r *http.Request) (*Claims, error) {
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
-			return nil, errors.New("authorization header is required")
+// AuthMiddleware validiert JWT-Tokens aus dem Authorization-Header oder dem access_token-Cookie
+// und legt die Claims unter ClaimsContextKey im Gin-Kontext ab.
+// Lesende Anfragen (GET, HEAD, OPTIONS) ohne Token werden nur durchgelassen, wenn anonymousRead gesetzt ist.
+// Ist users gesetzt, werden zusätzlich persönliche API-Tokens akzeptiert; Tokens mit Scope "read"
+// dürfen nur lesende Anfragen stellen. Die Rolle eines JWT wird dann ebenfalls bei jeder Anfrage aus dem
+// Benutzerkonto gelesen statt aus den Claims, sodass eine entzogene Rolle nicht bis zum Ablauf des Tokens fortbesteht.
+func AuthMiddleware(secret string, anonymousRead bool, users UserResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString, err := extractToken(c.Request)
+		if err != nil {
+			if errors.Is(err, errNoToken) && anonymousRead && isReadOnly(c.Request.Method) {
+				c.Next()
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		var claims *Claims
+		if users != nil && IsAPIToken(tokenString) {
+			claims, err = users.ResolveAPIToken(tokenString)
+		} else {
+			claims, err = ValidateToken(secret, tokenString)
+			if err == nil && users != nil && claims.Type != "refresh" {
+				claims.Role, err = users.CurrentRole(claims.UserID)
+			}
+		}
+		if err != nil || claims.Type == "refresh" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+			return
+		}
+
+		if claims.Scope == ScopeRead && !isReadOnly(c.Request.Method) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "token scope does not allow write access"})
+			return
+		}
+
+		c.Set(ClaimsContextKey, claims)
+		c.Next()
+	}
+}
+
+// RequireRole lässt nur Anfragen von Benutzern durch, deren Rolle mindestens role entspricht
+// Muss nach AuthMiddleware eingebunden werden
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := GetClaims(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": errNoToken.Error()})
+			return
+		}
+
+		if !HasRole(claims.Role, role) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// DenyAPITokens weist Anfragen ab, die mit einem persönlichen API-Token statt einer Anmeldung gestellt werden
+// Muss nach AuthMiddleware eingebunden werden
+func DenyAPITokens() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if claims, ok := GetClaims(c); ok && claims.Scope != "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "API tokens cannot be used for this endpoint"})
+			return
 		}
+		c.Next()
+	}
+}
 
-		if len(authHeader) <= len("Bearer ") || authHeader[:len("Bearer ")] != "Bearer " {
-			return nil, errors.New("invalid authorization header format")
+// HasRole prüft, ob role mindestens die Berechtigungen von required umfasst
+func HasRole(role, required string) bool {
+	rank, ok := roleRanks[role]
+	return ok && rank >= roleRanks[required]
+}
+
+// GetClaims liefert die von AuthMiddleware abgelegten Claims
+func GetClaims(c *gin.Context) (*Claims, bool) {
+	value, exists := c.Get(ClaimsContextKey)
+	if !exists {
+		return nil, false
+	}
+	claims, ok := value.(*Claims)
+	return claims, ok
+}
+
+// UserID liefert die ID des angemeldeten Benutzers oder 0 bei anonymen Anfragen
+func UserID(c *gin.Context) uint {
+	if claims, ok := GetClaims(c); ok {
+		return claims.UserID
+	}
+	return 0
+}
+
+func extractToken(r *http.Request) (string, error) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		if cookie, err := r.Cookie(AccessTokenCookie); err == nil && cookie.Value != "" {
+			return cookie.Value, nil
 		}
+		return "", errNoToken
+	}
 
-		tokenString := authHeader[len("Bearer "):]
-		return ValidateToken(secret, tokenString)
+	if len(authHeader) <= len("Bearer ") || authHeader[:len("Bearer ")] != "Bearer " {
+		return "", errors.New("invalid authorization header format")
 	}
+
+	return strings.TrimSpace(authHeader[len("Bearer "):]), nil
+}
+
+func isReadOnly(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
 }
@@ -1,13 +1,23 @@
 package auth
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	jwt "github.com/golang-jwt/jwt/v5"
 )
 
+// Namen der Cookies, in denen Access- und Refresh-Token ausgeliefert werden
+const (
+	AccessTokenCookie  = "access_token"
+	RefreshTokenCookie = "refresh_token"
+)
+
 type JWTConfig struct {
 	Secret            []byte
 	Expiration        time.Duration
@@ -44,21 +54,61 @@ func NewJWTConfig() (*JWTConfig, error) {
 }
 
 func (c *JWTConfig) GenerateToken(userID uint) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": userID,
-		"exp":     time.Now().Add(c.Expiration).Unix(),
-		"iat":     time.Now().Unix(),
+	return c.GenerateAccessToken(userID, "", "")
+}
+
+// GenerateAccessToken erzeugt einen Access-Token mit Benutzername und Rolle in den Claims
+func (c *JWTConfig) GenerateAccessToken(userID uint, username, role string) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{
+		UserID:   userID,
+		Username: username,
+		Role:     role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(c.Expiration)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
 	})
 
 	return token.SignedString(c.Secret)
 }
 
+// RefreshClaims sind die Claims eines Refresh-Tokens
+// ID (jti) kennzeichnet den einzelnen Token, Family alle Tokens, die durch Rotation aus derselben Anmeldung hervorgehen.
+// Generation wird beim Abmelden auf allen Geräten erhöht und macht ältere Refresh-Tokens ungültig.
+type RefreshClaims struct {
+	UserID     uint   `json:"user_id"`
+	Type       string `json:"type"`
+	Family     string `json:"fam"`
+	Generation int64  `json:"gen"`
+	jwt.RegisteredClaims
+}
+
+// GenerateRefreshToken erzeugt einen Refresh-Token, der eine neue Token-Familie beginnt
 func (c *JWTConfig) GenerateRefreshToken(userID uint) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": userID,
-		"exp":     time.Now().Add(c.RefreshExpiration).Unix(),
-		"iat":     time.Now().Unix(),
-		"type":    "refresh",
+	family, err := NewTokenID()
+	if err != nil {
+		return "", err
+	}
+	return c.GenerateFamilyRefreshToken(userID, family, 0)
+}
+
+// GenerateFamilyRefreshToken erzeugt einen Refresh-Token mit neuer jti innerhalb einer bestehenden Token-Familie
+func (c *JWTConfig) GenerateFamilyRefreshToken(userID uint, family string, generation int64) (string, error) {
+	id, err := NewTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &RefreshClaims{
+		UserID:     userID,
+		Type:       "refresh",
+		Family:     family,
+		Generation: generation,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        id,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(c.RefreshExpiration)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
 	})
 
 	return token.SignedString(c.Secret)
@@ -72,3 +122,54 @@ func (c *JWTConfig) ValidateToken(tokenString string) (*jwt.Token, error) {
 		return c.Secret, nil
 	})
 }
+
+// ParseRefreshToken prüft Signatur, Ablauf und Typ eines Refresh-Tokens und liefert seine Claims
+func (c *JWTConfig) ParseRefreshToken(tokenString string) (*RefreshClaims, error) {
+	claims := &RefreshClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return c.Secret, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.Valid {
+		return nil, fmt.Errorf("invalid token")
+	}
+	if claims.Type != "refresh" {
+		return nil, fmt.Errorf("token is not a refresh token")
+	}
+	if claims.UserID == 0 {
+		return nil, fmt.Errorf("token has no valid user_id")
+	}
+	if claims.ID == "" || claims.Family == "" {
+		return nil, fmt.Errorf("token has no jti or family")
+	}
+	return claims, nil
+}
+
+// NewTokenID erzeugt eine zufällige ID für jti- und Familien-Claims
+func NewTokenID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// SameSite übersetzt CookieSameSite in den entsprechenden http.SameSite-Wert
+func (c *JWTConfig) SameSite() http.SameSite {
+	switch strings.ToLower(c.CookieSameSite) {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "lax":
+		return http.SameSiteLaxMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteDefaultMode
+	}
+}
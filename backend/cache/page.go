@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	gincache "github.com/gin-contrib/cache"
+	"github.com/gin-contrib/cache/persistence"
+	"github.com/gin-gonic/gin"
+)
+
+// CachePageByUser funktioniert wie gincache.CachePage, legt die Einträge aber pro Benutzer ab,
+// damit gecachte Antworten nicht zwischen den Sammlungen verschiedener Benutzer geteilt werden.
+// Anonyme Anfragen teilen sich einen gemeinsamen Eintrag.
+func CachePageByUser(store persistence.CacheStore, expire time.Duration, handle gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		original := c.Request.URL
+
+		// gincache bildet den Schlüssel aus der Request-URI, daher wird der Pfad um die Benutzer-ID ergänzt
+		keyed := *original
+		keyed.Path = fmt.Sprintf("/user/%d%s", auth.UserID(c), original.Path)
+		keyed.RawPath = ""
+		c.Request.URL = &keyed
+
+		gincache.CachePage(store, expire, func(c *gin.Context) {
+			c.Request.URL = original
+			handle(c)
+		})(c)
+
+		c.Request.URL = original
+	}
+}
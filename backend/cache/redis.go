@@ -5,7 +5,9 @@ import (
 	"os"
 	"time"
 
+	gincache "github.com/gin-contrib/cache"
 	"github.com/gin-contrib/cache/persistence"
+	"github.com/gomodule/redigo/redis"
 )
 
 // CacheStore ist die Schnittstelle für alle Cache-Operationen
@@ -26,6 +28,9 @@ var (
 	RedisStore persistence.CacheStore
 	// DefaultExpiration ist die Standardablaufzeit für Cache-Einträge
 	DefaultExpiration = 5 * time.Minute
+
+	// redisPool ist gesetzt, wenn RedisStore auf Redis basiert
+	redisPool *redis.Pool
 )
 
 // InitRedisCache initialisiert den Cache-Speicher
@@ -36,14 +41,76 @@ func InitRedisCache() {
 	// Wenn REDIS_HOST definiert ist, verwende Redis, ansonsten In-Memory-Cache
 	if redisHost != "" {
 		log.Printf("Verwende Redis-Cache auf %s", redisHost)
-		// Verwende RedisCache aus dem persistence-Paket
-		RedisStore = persistence.NewRedisCache(redisHost, redisPassword, DefaultExpiration)
+		// Der Pool wird selbst verwaltet, damit ClearAllCaches nur Seiten-Cache-Einträge löscht
+		redisPool = newRedisPool(redisHost, redisPassword)
+		RedisStore = persistence.NewRedisCacheWithPool(redisPool, DefaultExpiration)
 	} else {
 		log.Printf("Verwende In-Memory-Cache (nur für Entwicklung/Tests)")
+		redisPool = nil
 		RedisStore = persistence.NewInMemoryStore(DefaultExpiration)
 	}
 }
 
+// RedisEnabled meldet, ob RedisStore auf Redis basiert und damit dauerhaft und instanzübergreifend ist
+func RedisEnabled() bool {
+	return redisPool != nil
+}
+
+func newRedisPool(host, password string) *redis.Pool {
+	return &redis.Pool{
+		MaxIdle:     5,
+		IdleTimeout: 240 * time.Second,
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", host,
+				redis.DialPassword(password),
+				redis.DialConnectTimeout(10*time.Second))
+		},
+		TestOnBorrow: func(c redis.Conn, t time.Time) error {
+			if time.Since(t) < 30*time.Second {
+				return nil
+			}
+			_, err := c.Do("PING")
+			return err
+		},
+	}
+}
+
+// flushPageCache leert den Seiten-Cache
+// Unter Redis werden nur Schlüssel mit dem Präfix des Seiten-Caches gelöscht, damit andere Daten
+// wie der Widerrufsstatus von Refresh-Tokens erhalten bleiben.
+func flushPageCache() error {
+	if redisPool == nil {
+		return RedisStore.Flush()
+	}
+
+	conn := redisPool.Get()
+	defer conn.Close()
+
+	cursor := 0
+	for {
+		values, err := redis.Values(conn.Do("SCAN", cursor, "MATCH", gincache.PageCachePrefix+"*", "COUNT", 100))
+		if err != nil {
+			return err
+		}
+		cursor, err = redis.Int(values[0], nil)
+		if err != nil {
+			return err
+		}
+		keys, err := redis.Strings(values[1], nil)
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 {
+			if _, err := conn.Do("DEL", redis.Args{}.AddFlat(keys)...); err != nil {
+				return err
+			}
+		}
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
 // ClearAllCaches löscht alle Caches
 func ClearAllCaches() {
 	// Liste der wichtigen API-Pfade, die gecacht werden
@@ -61,8 +128,8 @@ func ClearAllCaches() {
 		}
 	}
 
-	// Lösche alle Cache-Einträge
-	if err := RedisStore.Flush(); err != nil {
+	// Lösche alle Einträge des Seiten-Caches
+	if err := flushPageCache(); err != nil {
 		log.Printf("Fehler beim Leeren des gesamten Caches: %v", err)
 	}
 
@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gin-contrib/cache/persistence"
+	"github.com/gomodule/redigo/redis"
+)
+
+// revocationPrefix trennt Widerrufseinträge von den Schlüsseln des Seiten-Caches
+const revocationPrefix = "revocation:"
+
+// RevocationStore speichert den Widerrufsstatus von Refresh-Tokens in RedisStore
+// und implementiert services.RevocationStore
+type RevocationStore struct {
+	store persistence.CacheStore
+	pool  *redis.Pool
+}
+
+// NewRevocationStore verwendet den aktuellen RedisStore; InitRedisCache muss vorher aufgerufen worden sein
+func NewRevocationStore() *RevocationStore {
+	return &RevocationStore{store: RedisStore, pool: redisPool}
+}
+
+// Add legt key atomar an, sofern er noch nicht existiert
+// Unter Redis geschieht das per SET NX, da persistence.RedisStore.Add nicht atomar ist.
+func (s *RevocationStore) Add(key string, value int64, ttl time.Duration) (bool, error) {
+	key = revocationPrefix + key
+	if s.pool != nil {
+		conn := s.pool.Get()
+		defer conn.Close()
+
+		args := redis.Args{key, value, "NX"}
+		if ttl > 0 {
+			args = args.Add("PX", ttl.Milliseconds())
+		}
+		reply, err := conn.Do("SET", args...)
+		if err != nil {
+			return false, err
+		}
+		return reply != nil, nil
+	}
+
+	err := s.store.Add(key, value, expiration(ttl))
+	if errors.Is(err, persistence.ErrNotStored) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *RevocationStore) Set(key string, value int64, ttl time.Duration) error {
+	return s.store.Set(revocationPrefix+key, value, expiration(ttl))
+}
+
+// Increment zählt unter Redis per INCR; der In-Memory-Store legt key zunächst mit 0 an und erhöht ihn unter seiner Sperre
+func (s *RevocationStore) Increment(key string) (int64, error) {
+	key = revocationPrefix + key
+	if s.pool != nil {
+		conn := s.pool.Get()
+		defer conn.Close()
+		return redis.Int64(conn.Do("INCR", key))
+	}
+
+	if err := s.store.Add(key, int64(0), persistence.FOREVER); err != nil && !errors.Is(err, persistence.ErrNotStored) {
+		return 0, err
+	}
+	value, err := s.store.Increment(key, 1)
+	return int64(value), err
+}
+
+func (s *RevocationStore) Get(key string) (int64, bool, error) {
+	var value int64
+	err := s.store.Get(revocationPrefix+key, &value)
+	if errors.Is(err, persistence.ErrCacheMiss) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return value, true, nil
+}
+
+// expiration übersetzt eine TTL von 0 in persistence.FOREVER, da 0 im CacheStore die Standardablaufzeit bedeutet
+func expiration(ttl time.Duration) time.Duration {
+	if ttl <= 0 {
+		return persistence.FOREVER
+	}
+	return ttl
+}
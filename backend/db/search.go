@@ -0,0 +1,76 @@
+package db
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// DefaultSearchConfig ist die Textsuchkonfiguration von PostgreSQL, wenn SEARCH_LANGUAGE nicht gesetzt ist
+const DefaultSearchConfig = "german"
+
+var searchConfigPattern = regexp.MustCompile(`^[a-z_]+$`)
+
+// SearchConfig liefert die Textsuchkonfiguration (z. B. german, english, simple) aus SEARCH_LANGUAGE
+// Der Name landet in DDL-Anweisungen und wird deshalb auf Kleinbuchstaben und Unterstriche beschränkt.
+func SearchConfig() string {
+	config := strings.ToLower(strings.TrimSpace(os.Getenv("SEARCH_LANGUAGE")))
+	if config == "" {
+		return DefaultSearchConfig
+	}
+	if !searchConfigPattern.MatchString(config) {
+		log.Printf("Ungültige SEARCH_LANGUAGE %q, verwende %s", config, DefaultSearchConfig)
+		return DefaultSearchConfig
+	}
+	return config
+}
+
+// EnsureFullTextSearch legt auf PostgreSQL die generierte Spalte movies.search_vector samt GIN-Index an
+// Titel, Inhaltsangabe und Beschreibung werden absteigend gewichtet. Ändert sich die Konfiguration,
+// wird die Spalte neu erzeugt. Auf anderen Datenbanken passiert nichts.
+func EnsureFullTextSearch(database *gorm.DB, config string) error {
+	if database.Dialector.Name() != "postgres" {
+		return nil
+	}
+	if !searchConfigPattern.MatchString(config) {
+		return fmt.Errorf("ungültige Textsuchkonfiguration: %q", config)
+	}
+
+	var expression string
+	if err := database.Raw(
+		"SELECT COALESCE(generation_expression, '') FROM information_schema.columns WHERE table_name = 'movies' AND column_name = 'search_vector'",
+	).Scan(&expression).Error; err != nil {
+		return err
+	}
+
+	if !strings.Contains(expression, "'"+config+"'::regconfig") {
+		if err := database.Exec("ALTER TABLE movies DROP COLUMN IF EXISTS search_vector").Error; err != nil {
+			return err
+		}
+		if err := database.Exec(fmt.Sprintf(`ALTER TABLE movies ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+			setweight(to_tsvector('%[1]s', coalesce(title, '')), 'A') ||
+			setweight(to_tsvector('%[1]s', coalesce(overview, '')), 'B') ||
+			setweight(to_tsvector('%[1]s', coalesce(description, '')), 'C')
+		) STORED`, config)).Error; err != nil {
+			return err
+		}
+	}
+
+	return database.Exec("CREATE INDEX IF NOT EXISTS idx_movies_search_vector ON movies USING GIN (search_vector)").Error
+}
+
+// EnsureTrigramSearch aktiviert auf PostgreSQL pg_trgm und indiziert den gefalteten Suchtitel
+// Das Anlegen der Erweiterung kann fehlende Rechte scheitern lassen; dann bleibt die Editierdistanz-Suche aktiv.
+func EnsureTrigramSearch(database *gorm.DB) error {
+	if database.Dialector.Name() != "postgres" {
+		return nil
+	}
+	if err := database.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		return err
+	}
+	return database.Exec("CREATE INDEX IF NOT EXISTS idx_movies_search_title_trgm ON movies USING GIN (search_title gin_trgm_ops)").Error
+}
@@ -24,9 +24,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/movies": {
-            "get": {
-                "description": "Gibt eine Liste aller gespeicherten Filme zurück",
+        "/auth/login": {
+            "post": {
+                "description": "Prüft die Anmeldedaten und setzt Access- und Refresh-Token als Cookies",
                 "consumes": [
                     "application/json"
                 ],
@@ -34,17 +34,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "movies"
+                    "auth"
+                ],
+                "summary": "Anmelden",
+                "parameters": [
+                    {
+                        "description": "Benutzername und Passwort",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Credentials"
+                        }
+                    }
                 ],
-                "summary": "Liste aller Filme abrufen",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Movie"
-                            }
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
@@ -54,9 +74,101 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "description": "Widerruft die Sitzung des Refresh-Token-Cookies und entfernt die Token-Cookies",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Abmelden",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SwaggerResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout-all": {
+            "post": {
+                "description": "Widerruft alle Refresh-Tokens des angemeldeten Benutzers; bestehende Access-Tokens laufen regulär ab",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Auf allen Geräten abmelden",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SwaggerResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Rotiert den Refresh-Token aus dem Cookie und stellt ein neues Token-Paar aus. Ein bereits verwendeter Refresh-Token beendet die gesamte Sitzung.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Tokens erneuern",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
             "post": {
-                "description": "Erstellt einen neuen Film in der Datenbank",
+                "description": "Legt ein neues Benutzerkonto an. Das erste Konto wird Administrator, alle weiteren starten als Bearbeiter ihrer eigenen Sammlung.",
                 "consumes": [
                     "application/json"
                 ],
@@ -64,17 +176,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "movies"
+                    "auth"
                 ],
-                "summary": "Neuen Film erstellen",
+                "summary": "Benutzer registrieren",
                 "parameters": [
                     {
-                        "description": "Movie Information",
-                        "name": "movie",
+                        "description": "Benutzername und Passwort",
+                        "name": "credentials",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Movie"
+                            "$ref": "#/definitions/models.Credentials"
                         }
                     }
                 ],
@@ -82,7 +194,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Movie"
+                            "$ref": "#/definitions/models.User"
                         }
                     },
                     "400": {
@@ -91,6 +203,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -100,51 +218,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/movies/{id}": {
+        "/auth/tokens": {
             "get": {
-                "description": "Gibt einen spezifischen Film anhand seiner ID zurück",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Gibt alle persönlichen API-Tokens des angemeldeten Benutzers ohne Klartext zurück",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "movies"
-                ],
-                "summary": "Einzelnen Film abrufen",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Movie ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "Eigene API-Tokens auflisten",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Movie"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.APIToken"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             },
-            "put": {
-                "description": "Aktualisiert einen bestehenden Film",
+            "post": {
+                "description": "Legt einen persönlichen API-Token an; der Klartext wird nur in dieser Antwort zurückgegeben",
                 "consumes": [
                     "application/json"
                 ],
@@ -152,32 +261,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "movies"
+                    "auth"
                 ],
-                "summary": "Film aktualisieren",
+                "summary": "API-Token anlegen",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Movie ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Movie Information",
-                        "name": "movie",
+                        "description": "Name und Scope (read oder write)",
+                        "name": "token",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Movie"
+                            "$ref": "#/definitions/models.APITokenRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Movie"
+                            "$ref": "#/definitions/models.CreatedAPIToken"
                         }
                     },
                     "400": {
@@ -186,8 +288,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -199,23 +301,22 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/tokens/{id}": {
             "delete": {
-                "description": "Löscht einen Film aus der Datenbank",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Löscht einen persönlichen API-Token des angemeldeten Benutzers",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "movies"
+                    "auth"
                 ],
-                "summary": "Film löschen",
+                "summary": "API-Token widerrufen",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Movie ID",
+                        "description": "Token ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -234,14 +335,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -249,32 +350,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/movies/{id}/image": {
+        "/genres": {
             "get": {
-                "description": "Gibt das Bild eines spezifischen Films zurück",
+                "description": "Gibt alle Genres der Sammlung mit der Anzahl ihrer Filme zurück",
                 "produces": [
-                    "image/jpeg",
-                    "image/png",
-                    "image/gif"
+                    "application/json"
                 ],
                 "tags": [
-                    "images"
+                    "genres"
                 ],
-                "summary": "Bild eines Films abrufen",
+                "summary": "Genres auflisten",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Movie ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "enum": [
+                            "owned",
+                            "wishlist",
+                            "preordered",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Status (Standard: owned)",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "file"
+                            "$ref": "#/definitions/models.GenreListResponse"
                         }
                     },
                     "400": {
@@ -283,47 +387,62 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Lädt ein Bild für einen spezifischen Film hoch",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+            }
+        },
+        "/genres/{id}/movies": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "images"
+                    "genres"
                 ],
-                "summary": "Bild für einen Film hochladen",
+                "summary": "Filme eines Genres",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Movie ID",
+                        "description": "Genre ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "Image file",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
+                        "enum": [
+                            "owned",
+                            "wishlist",
+                            "preordered",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Status (Standard: owned)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Seitennummer (Standard: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Einträge pro Seite (Standard: 20, Max: 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SwaggerResponse"
+                            "$ref": "#/definitions/models.PaginatedResponse"
                         }
                     },
                     "400": {
@@ -345,42 +464,26 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Löscht das Bild eines spezifischen Films",
+            }
+        },
+        "/loans": {
+            "get": {
+                "description": "Gibt alle laufenden Ausleihen nach Fälligkeit sortiert zurück",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "images"
-                ],
-                "summary": "Bild eines Films löschen",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Movie ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "loans"
                 ],
+                "summary": "Verliehene Filme auflisten",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SwaggerResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Loan"
+                            }
                         }
                     },
                     "500": {
@@ -391,71 +494,3304 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.ErrorResponse": {
-            "type": "object",
-            "properties": {
+        },
+        "/loans/overdue": {
+            "get": {
+                "description": "Gibt alle laufenden Ausleihen zurück, deren Fälligkeitsdatum überschritten ist",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loans"
+                ],
+                "summary": "Überfällige Ausleihen auflisten",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Loan"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations": {
+            "get": {
+                "description": "Gibt alle Lagerorte der eigenen Sammlung sortiert nach Raum, Regal und Fach zurück",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Lagerorte auflisten",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Location"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Lagerort anlegen",
+                "parameters": [
+                    {
+                        "description": "Raum, Regal und Fach",
+                        "name": "location",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Location"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Location"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Einzelnen Lagerort abrufen",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Location"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Lagerort aktualisieren",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Raum, Regal und Fach",
+                        "name": "location",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Location"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Location"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Löscht einen Lagerort; zugeordnete Filme und Exemplare verlieren ihre Zuordnung",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Lagerort löschen",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SwaggerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{id}/movies": {
+            "get": {
+                "description": "Gibt die Filme zurück, die selbst oder mit einem Exemplar an diesem Lagerort stehen",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Filme an einem Lagerort",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Seitennummer (Standard: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Einträge pro Seite (Standard: 20, Max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies": {
+            "get": {
+                "description": "Gibt eine sortierte, paginierte Liste der Filme im Bestand samt Facetten (Jahrzehnte, Bewertungsbereiche) zurück; Wunschliste und Vorbestellungen über status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Liste aller Filme abrufen",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Seitennummer (Standard: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Einträge pro Seite (Standard: 20, Max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyset-Paginierung: leer für die erste Seite, danach next_cursor bzw. prev_cursor aus meta; ersetzt page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Gesamtanzahl und Facetten zählen (Standard: true); false spart die Zählabfragen",
+                        "name": "include_total",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "title",
+                            "year",
+                            "rating",
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "description": "Sortierung (Standard: title)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Richtung (Standard: asc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "dvd",
+                            "bluray",
+                            "uhd",
+                            "digital"
+                        ],
+                        "type": "string",
+                        "description": "Medienformat",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Regionalcode (0-8 für DVD, A/B/C für Blu-ray, free)",
+                        "name": "region",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Teil des Editionsnamens, z. B. Steelbook",
+                        "name": "edition",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Anzahl der Discs",
+                        "name": "disc_count",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "owned",
+                            "wishlist",
+                            "preordered",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Status (Standard: owned)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Erscheinungsjahr ab",
+                        "name": "year_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Erscheinungsjahr bis",
+                        "name": "year_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Mindestbewertung (0-10)",
+                        "name": "rating_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Nur Filme mit (true) oder ohne (false) Bild oder Poster",
+                        "name": "has_image",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Nur Filme mit (true) oder ohne (false) TMDB-Verknüpfung",
+                        "name": "tmdb_linked",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Anfangsbuchstabe ohne Artikel (A-Z) oder # für Ziffern und Zeichen",
+                        "name": "starts_with",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Erstellt einen neuen Film in der Datenbank; mit tmdb_id werden Genres, Laufzeit und Credits aus TMDB übernommen, sofern ein TMDB-Schlüssel konfiguriert ist. Schlägt das fehl, bleibt der Film ohne Metadaten gespeichert.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Neuen Film erstellen",
+                "parameters": [
+                    {
+                        "description": "Movie Information",
+                        "name": "movie",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Movie"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Movie"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/index": {
+            "get": {
+                "description": "Gibt für jeden Anfangsbuchstaben der nach Titel sortierten Liste die Anzahl der Filme und die Seite bzw. den Cursor zurück, an dem der erste davon steht. Führende Artikel (The, Der, Die, Das) werden übersprungen, Ziffern und Zeichen unter \"#\" zusammengefasst. Es gelten dieselben Filter wie bei GET /movies.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Alphabet-Index der Filmliste",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Einträge pro Seite, auf die sich page bezieht (Standard: 20, Max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Richtung der Titelsortierung (Standard: asc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "dvd",
+                            "bluray",
+                            "uhd",
+                            "digital"
+                        ],
+                        "type": "string",
+                        "description": "Medienformat",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Regionalcode (0-8 für DVD, A/B/C für Blu-ray, free)",
+                        "name": "region",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Teil des Editionsnamens, z. B. Steelbook",
+                        "name": "edition",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Anzahl der Discs",
+                        "name": "disc_count",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "owned",
+                            "wishlist",
+                            "preordered",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Status (Standard: owned)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Erscheinungsjahr ab",
+                        "name": "year_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Erscheinungsjahr bis",
+                        "name": "year_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Mindestbewertung (0-10)",
+                        "name": "rating_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Nur Filme mit (true) oder ohne (false) Bild oder Poster",
+                        "name": "has_image",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Nur Filme mit (true) oder ohne (false) TMDB-Verknüpfung",
+                        "name": "tmdb_linked",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AlphabetIndexResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/search": {
+            "get": {
+                "description": "Durchsucht die Filmdatenbank nach einem Suchbegriff. q unterstützt eine Suchsprache mit den Feldern year, rating, runtime, discs (Zahl, \u003e7, \u003c=2000, 1990..1999), format, region, status, edition, title, genre, director und actor (Wert oder \"in Anführungszeichen\"); ein führendes \"-\" negiert. Syntaxfehler liefern 400 mit position. Mit fuzzy=true werden Titel tippfehlertolerant verglichen und jeder Treffer enthält eine Ähnlichkeit (score, FuzzySearchResponse).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Filme suchen",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Suchbegriff, z. B. year:1990..1999 rating:\u003e7 format:bluray director:\\",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Tippfehlertolerante Titelsuche mit Ähnlichkeitswert",
+                        "name": "fuzzy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Seitennummer (Standard: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Einträge pro Seite (Standard: 20, Max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyset-Paginierung: leer für die erste Seite, danach next_cursor bzw. prev_cursor aus meta; nicht mit fuzzy",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Gesamtanzahl zählen (Standard: true)",
+                        "name": "include_total",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "dvd",
+                            "bluray",
+                            "uhd",
+                            "digital"
+                        ],
+                        "type": "string",
+                        "description": "Medienformat",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Regionalcode (0-8 für DVD, A/B/C für Blu-ray, free)",
+                        "name": "region",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Teil des Editionsnamens, z. B. Steelbook",
+                        "name": "edition",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Anzahl der Discs",
+                        "name": "disc_count",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "owned",
+                            "wishlist",
+                            "preordered",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Erscheinungsjahr ab",
+                        "name": "year_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Erscheinungsjahr bis",
+                        "name": "year_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Mindestbewertung (0-10)",
+                        "name": "rating_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Nur Filme mit (true) oder ohne (false) Bild oder Poster",
+                        "name": "has_image",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Nur Filme mit (true) oder ohne (false) TMDB-Verknüpfung",
+                        "name": "tmdb_linked",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "title",
+                            "year",
+                            "rating",
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "description": "Sortierung (Standard: title)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Richtung (Standard: asc)",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.QueryErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/{id}": {
+            "get": {
+                "description": "Gibt einen spezifischen Film anhand seiner ID zurück",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Einzelnen Film abrufen",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Movie"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Aktualisiert einen bestehenden Film; eine neue tmdb_id übernimmt wie beim Anlegen Genres, Laufzeit und Credits aus TMDB",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Film aktualisieren",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Movie Information",
+                        "name": "movie",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Movie"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Movie"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Löscht einen Film aus der Datenbank",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Film löschen",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SwaggerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/{id}/acquire": {
+            "post": {
+                "description": "Übernimmt einen Film von der Wunschliste oder aus einer Vorbestellung in den Bestand; TMDB-Daten bleiben erhalten",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Film in den Bestand übernehmen",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Movie"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/{id}/copies": {
+            "get": {
+                "description": "Gibt alle Exemplare (z. B. DVD und Blu-ray) eines Films zurück",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "copies"
+                ],
+                "summary": "Exemplare eines Films auflisten",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Copy"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Fügt einem Film ein weiteres Exemplar hinzu",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "copies"
+                ],
+                "summary": "Exemplar anlegen",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Exemplar",
+                        "name": "copy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Copy"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Copy"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/{id}/copies/{copyId}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "copies"
+                ],
+                "summary": "Einzelnes Exemplar abrufen",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Copy ID",
+                        "name": "copyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Copy"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "copies"
+                ],
+                "summary": "Exemplar aktualisieren",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Copy ID",
+                        "name": "copyId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Exemplar",
+                        "name": "copy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Copy"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Copy"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "copies"
+                ],
+                "summary": "Exemplar löschen",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Copy ID",
+                        "name": "copyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SwaggerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/{id}/image": {
+            "get": {
+                "description": "Gibt das Bild eines spezifischen Films zurück",
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/gif"
+                ],
+                "tags": [
+                    "images"
+                ],
+                "summary": "Bild eines Films abrufen",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Lädt ein Bild für einen spezifischen Film hoch",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "images"
+                ],
+                "summary": "Bild für einen Film hochladen",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SwaggerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Löscht das Bild eines spezifischen Films",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "images"
+                ],
+                "summary": "Bild eines Films löschen",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SwaggerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/{id}/loans": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loans"
+                ],
+                "summary": "Ausleihhistorie eines Films",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Loan"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Verleiht einen Film; ohne lent_on gilt das heutige Datum",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loans"
+                ],
+                "summary": "Film verleihen",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ausleihe",
+                        "name": "loan",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LoanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Loan"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/{id}/metadata": {
+            "post": {
+                "description": "Lädt Genres, Laufzeit, Besetzung und Stab eines mit TMDB verknüpften Films und speichert sie lokal",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "TMDB-Metadaten übernehmen",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Movie"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/{id}/return": {
+            "post": {
+                "description": "Beendet die laufende Ausleihe; ohne returned_on gilt das heutige Datum",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loans"
+                ],
+                "summary": "Film zurückgeben",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rückgabe",
+                        "name": "return",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReturnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Loan"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/people/{id}/movies": {
+            "get": {
+                "description": "Gibt die Filme der Sammlung zurück, in denen eine Person mitspielt oder im Stab mitwirkt",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "people"
+                ],
+                "summary": "Filme einer Person",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "cast",
+                            "crew"
+                        ],
+                        "type": "string",
+                        "description": "Nur Besetzung oder nur Stab",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Aufgabe im Stab, z. B. Director",
+                        "name": "job",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "owned",
+                            "wishlist",
+                            "preordered",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Status (Standard: owned)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Seitennummer (Standard: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Einträge pro Seite (Standard: 20, Max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tmdb/movie/{id}": {
+            "get": {
+                "description": "Lädt die Details eines Films bei TMDB einschließlich Genres, Laufzeit, Credits und Originaltitel. Fehlt eine übersetzte Beschreibung, wird die englische geliefert.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tmdb"
+                ],
+                "summary": "TMDB-Filmdetails abrufen",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TMDB-ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sprache der Metadaten, z. B. de-DE",
+                        "name": "language",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tmdb.Movie"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tmdb/search": {
+            "get": {
+                "description": "Sucht Filme (type=movie, Standard), Serien (type=tv) oder beides (type=multi) bei TMDB nach Titel. Serien und Treffer der Mehrfachsuche enthalten media_type. Ohne Angabe gelten Sprache und Region aus TMDB_LANGUAGE und TMDB_REGION; fehlt eine übersetzte Beschreibung, wird die englische geliefert. Fehler von TMDB werden als 404, 429 (mit Retry-After), 502, 503 oder 504 gemeldet.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tmdb"
+                ],
+                "summary": "Filme und Serien bei TMDB suchen",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Suchbegriff",
+                        "name": "query",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "movie",
+                            "tv",
+                            "multi"
+                        ],
+                        "type": "string",
+                        "description": "Art der Suche",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sprache der Metadaten, z. B. de-DE",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Region nach ISO 3166-1, z. B. DE",
+                        "name": "region",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/tmdb.Movie"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tmdb/stats": {
+            "get": {
+                "description": "Zähler der TMDB-Aufrufe seit dem Start: zusammengelegte Aufrufe, gesendete Anfragen, Wiederholungen, gedrosselte Anfragen, 429-Antworten und Treffer im Antwort-Cache (nur Administratoren)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tmdb"
+                ],
+                "summary": "TMDB-Anfragestatistik",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tmdb.Stats"
+                        }
+                    }
+                }
+            }
+        },
+        "/tmdb/test": {
+            "get": {
+                "description": "Prüft Erreichbarkeit und API-Schlüssel von TMDB (nur Administratoren)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tmdb"
+                ],
+                "summary": "TMDB-Verbindung prüfen",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tmdb/tv/{id}": {
+            "get": {
+                "description": "Lädt die Details einer Serie bei TMDB einschließlich Staffeln, Genres und Credits. Fehlt eine übersetzte Beschreibung, wird die englische geliefert.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tmdb"
+                ],
+                "summary": "TMDB-Seriendetails abrufen",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TMDB-ID der Serie",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sprache der Metadaten, z. B. de-DE",
+                        "name": "language",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tmdb.TVShow"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tmdb/tv/{id}/season/{season}": {
+            "get": {
+                "description": "Lädt eine Staffel einer Serie bei TMDB mit ihren Episoden; Staffel 0 enthält die Specials",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tmdb"
+                ],
+                "summary": "TMDB-Staffel abrufen",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TMDB-ID der Serie",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Staffelnummer",
+                        "name": "season",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sprache der Metadaten, z. B. de-DE",
+                        "name": "language",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tmdb.Season"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Gibt alle Benutzerkonten mit ihren Rollen zurück (nur für Administratoren)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Benutzer auflisten",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/role": {
+            "put": {
+                "description": "Setzt die Rolle (viewer, editor, admin) eines Benutzers (nur für Administratoren)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Rolle eines Benutzers ändern",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Neue Rolle",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RoleUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/version": {
+            "get": {
+                "description": "Gibt die aktuelle Version der API zurück",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "version"
+                ],
+                "summary": "Version der API abrufen",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.APIToken": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "scope": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.APITokenRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scope"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "scope": {
+                    "type": "string",
+                    "enum": [
+                        "read",
+                        "write"
+                    ]
+                }
+            }
+        },
+        "models.AlphabetEntry": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "cursor": {
+                    "description": "Cursor für GET /movies?cursor=...; leer für den Anfang der Liste",
+                    "type": "string",
+                    "example": "eyJzIjoidGl0bGUiLCJvIjoiYXNjIiwidiI6Ikx1Y3kiLCJpZCI6N30"
+                },
+                "letter": {
+                    "type": "string",
+                    "example": "M"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.AlphabetIndexMeta": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 230
+                }
+            }
+        },
+        "models.AlphabetIndexResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AlphabetEntry"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.AlphabetIndexMeta"
+                }
+            }
+        },
+        "models.Copy": {
+            "type": "object",
+            "required": [
+                "format"
+            ],
+            "properties": {
+                "barcode": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "5051890012345"
+                },
+                "condition": {
+                    "type": "string",
+                    "enum": [
+                        "new",
+                        "like_new",
+                        "good",
+                        "fair",
+                        "poor"
+                    ]
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "dvd",
+                        "bluray",
+                        "uhd",
+                        "digital"
+                    ],
+                    "example": "bluray"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "location": {
+                    "$ref": "#/definitions/models.Location"
+                },
+                "location_id": {
+                    "type": "integer"
+                },
+                "movie_id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 19.99
+                },
+                "purchase_date": {
+                    "type": "string",
+                    "example": "2024-03-15"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreatedAPIToken": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "scope": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Credentials": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 3
+                }
+            }
+        },
+        "models.Credit": {
+            "type": "object",
+            "properties": {
+                "character": {
+                    "type": "string",
+                    "example": "Neo"
+                },
+                "department": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "job": {
+                    "type": "string",
+                    "example": "Director"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "cast",
+                        "crew"
+                    ]
+                },
+                "movie_id": {
+                    "type": "integer"
+                },
+                "order": {
+                    "type": "integer"
+                },
+                "person": {
+                    "$ref": "#/definitions/models.Person"
+                },
+                "person_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DecadeFacet": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "decade": {
+                    "type": "integer",
+                    "example": 1990
+                }
+            }
+        },
+        "models.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "error message"
+                }
+            }
+        },
+        "models.Genre": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Science Fiction"
+                },
+                "tmdb_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GenreCount": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "movie_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Science Fiction"
+                },
+                "tmdb_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GenreListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GenreCount"
+                    }
+                },
+                "meta": {
+                    "type": "object",
+                    "properties": {
+                        "total": {
+                            "type": "integer"
+                        }
+                    }
+                }
+            }
+        },
+        "models.Loan": {
+            "type": "object",
+            "properties": {
+                "borrower_contact": {
+                    "type": "string",
+                    "example": "max@example.com"
+                },
+                "borrower_name": {
+                    "type": "string",
+                    "example": "Max Mustermann"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "due_on": {
+                    "type": "string",
+                    "example": "2024-03-15"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lent_on": {
+                    "type": "string",
+                    "example": "2024-03-01"
+                },
+                "movie": {
+                    "$ref": "#/definitions/models.Movie"
+                },
+                "movie_id": {
+                    "type": "integer"
+                },
+                "returned_on": {
+                    "type": "string",
+                    "example": "2024-03-12"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LoanRequest": {
+            "type": "object",
+            "required": [
+                "borrower_name"
+            ],
+            "properties": {
+                "borrower_contact": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "borrower_name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "due_on": {
+                    "type": "string"
+                },
+                "lent_on": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Location": {
+            "type": "object",
+            "required": [
+                "room"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "owner_id": {
+                    "type": "integer"
+                },
+                "room": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Wohnzimmer"
+                },
+                "shelf": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Regal 2"
+                },
+                "slot": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Fach 3"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Movie": {
+            "type": "object",
+            "required": [
+                "title",
+                "year"
+            ],
+            "properties": {
+                "active_loan": {
+                    "$ref": "#/definitions/models.Loan"
+                },
+                "copies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Copy"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "credits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Credit"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "disc_count": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 2
+                },
+                "edition": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Director's Cut"
+                },
+                "episode_count": {
+                    "type": "integer",
+                    "maximum": 10000,
+                    "minimum": 1,
+                    "example": 62
+                },
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "dvd",
+                        "bluray",
+                        "uhd",
+                        "digital"
+                    ],
+                    "example": "bluray"
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Genre"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "image_path": {
+                    "type": "string"
+                },
+                "location": {
+                    "$ref": "#/definitions/models.Location"
+                },
+                "location_id": {
+                    "type": "integer"
+                },
+                "media_type": {
+                    "description": "MediaType unterscheidet Filme und Serien; eine Staffel-Box umfasst die Staffeln SeasonFrom bis SeasonTo",
+                    "type": "string",
+                    "enum": [
+                        "movie",
+                        "tv"
+                    ],
+                    "example": "tv"
+                },
+                "original_title": {
+                    "description": "OriginalTitle ist der Titel in der Originalsprache, Title der lokalisierte",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "The Matrix"
+                },
+                "overview": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "type": "integer"
+                },
+                "poster_path": {
+                    "type": "string"
+                },
+                "preferred_format": {
+                    "type": "string",
+                    "enum": [
+                        "dvd",
+                        "bluray",
+                        "uhd",
+                        "digital"
+                    ]
+                },
+                "priority": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1,
+                    "example": 1
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "region": {
+                    "type": "string",
+                    "enum": [
+                        "0",
+                        "1",
+                        "2",
+                        "3",
+                        "4",
+                        "5",
+                        "6",
+                        "7",
+                        "8",
+                        "A",
+                        "B",
+                        "C",
+                        "free"
+                    ],
+                    "example": "B"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "runtime": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 136
+                },
+                "season_from": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 1
+                },
+                "season_to": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 5
+                },
+                "sort_title": {
+                    "description": "SortTitle bestimmt die alphabetische Einordnung; leer wird er aus Title ohne führenden Artikel abgeleitet",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Matrix"
+                },
+                "sort_title_custom": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "owned",
+                        "wishlist",
+                        "preordered"
+                    ],
+                    "example": "owned"
+                },
+                "target_price": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 14.99
+                },
+                "title": {
+                    "type": "string"
+                },
+                "tmdb_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MovieFacets": {
+            "type": "object",
+            "properties": {
+                "decades": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DecadeFacet"
+                    }
+                },
+                "ratings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RatingFacet"
+                    }
+                }
+            }
+        },
+        "models.PaginatedResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Movie"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                }
+            }
+        },
+        "models.PaginationMeta": {
+            "type": "object",
+            "properties": {
+                "facets": {
+                    "description": "Nur bei GET /movies; fehlt bei include_total=false",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MovieFacets"
+                        }
+                    ]
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "description": "Nur im Cursor-Modus; fehlt, wenn es keine weitere Seite gibt",
+                    "type": "string",
+                    "example": "eyJzIjoidGl0bGUiLCJvIjoiYXNjIiwidiI6IkFsaWVuIiwiaWQiOjQyfQ"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "prev_cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Person": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Keanu Reeves"
+                },
+                "profile_path": {
+                    "type": "string"
+                },
+                "tmdb_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.QueryErrorResponse": {
+            "type": "object",
+            "properties": {
                 "error": {
                     "type": "string",
-                    "example": "error message"
+                    "example": "Syntaxfehler an Position 5: Ungültige Zahl \"199x\""
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 5
                 }
             }
         },
-        "models.Movie": {
+        "models.RatingFacet": {
+            "type": "object",
+            "properties": {
+                "band": {
+                    "type": "string",
+                    "example": "7-8"
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 17
+                },
+                "max": {
+                    "type": "number",
+                    "example": 8
+                },
+                "min": {
+                    "type": "number",
+                    "example": 7
+                }
+            }
+        },
+        "models.ReturnRequest": {
+            "type": "object",
+            "properties": {
+                "returned_on": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RoleUpdate": {
             "type": "object",
             "required": [
-                "title",
-                "year"
+                "role"
             ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "viewer",
+                        "editor",
+                        "admin"
+                    ]
+                }
+            }
+        },
+        "models.SwaggerResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.User": {
+            "type": "object",
             "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "description": {
+                "id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "tmdb.CacheStats": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "integer",
+                    "example": 250
+                },
+                "evictions": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "hits": {
+                    "type": "integer",
+                    "example": 900
+                },
+                "misses": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "tmdb.CastMember": {
+            "type": "object",
+            "properties": {
+                "character": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "image_path": {
+                "name": {
+                    "type": "string"
+                },
+                "order": {
+                    "type": "integer"
+                },
+                "profile_path": {
+                    "type": "string"
+                }
+            }
+        },
+        "tmdb.Credits": {
+            "type": "object",
+            "properties": {
+                "cast": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tmdb.CastMember"
+                    }
+                },
+                "crew": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tmdb.CrewMember"
+                    }
+                }
+            }
+        },
+        "tmdb.CrewMember": {
+            "type": "object",
+            "properties": {
+                "department": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "job": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "profile_path": {
+                    "type": "string"
+                }
+            }
+        },
+        "tmdb.Episode": {
+            "type": "object",
+            "properties": {
+                "air_date": {
+                    "type": "string"
+                },
+                "episode_number": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
                 "overview": {
                     "type": "string"
                 },
-                "poster_path": {
+                "runtime": {
+                    "type": "integer"
+                },
+                "season_number": {
+                    "type": "integer"
+                },
+                "still_path": {
                     "type": "string"
                 },
-                "rating": {
+                "vote_average": {
                     "type": "number"
+                }
+            }
+        },
+        "tmdb.Genre": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "tmdb.Movie": {
+            "type": "object",
+            "properties": {
+                "credits": {
+                    "$ref": "#/definitions/tmdb.Credits"
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tmdb.Genre"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "media_type": {
+                    "description": "MediaType ist nur bei Serien- und Mehrfachsuche gesetzt (\"movie\" oder \"tv\")",
+                    "type": "string"
+                },
+                "original_language": {
+                    "type": "string"
+                },
+                "original_title": {
+                    "description": "OriginalTitle ist der Titel in der Originalsprache, Title der lokalisierte",
+                    "type": "string"
+                },
+                "overview": {
+                    "type": "string"
+                },
+                "poster_path": {
+                    "type": "string"
                 },
                 "release_date": {
                     "type": "string"
                 },
+                "runtime": {
+                    "type": "integer"
+                },
                 "title": {
                     "type": "string"
                 },
-                "tmdb_id": {
+                "vote_average": {
+                    "type": "number"
+                }
+            }
+        },
+        "tmdb.Season": {
+            "type": "object",
+            "properties": {
+                "air_date": {
                     "type": "string"
                 },
-                "updated_at": {
+                "episode_count": {
+                    "type": "integer"
+                },
+                "episodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tmdb.Episode"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "year": {
+                "overview": {
+                    "type": "string"
+                },
+                "poster_path": {
+                    "type": "string"
+                },
+                "season_number": {
                     "type": "integer"
                 }
             }
         },
-        "models.SwaggerResponse": {
+        "tmdb.Stats": {
             "type": "object",
             "properties": {
-                "error": {
+                "cache": {
+                    "description": "Cache sind die Zähler des Caches im Prozess",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/tmdb.CacheStats"
+                        }
+                    ]
+                },
+                "calls": {
+                    "description": "Calls sind die Aufrufe der Client-Methoden",
+                    "type": "integer",
+                    "example": 120
+                },
+                "coalesced": {
+                    "description": "Coalesced sind Aufrufe, die eine bereits laufende identische Anfrage mitgenutzt haben",
+                    "type": "integer",
+                    "example": 14
+                },
+                "errors": {
+                    "description": "Errors sind Aufrufe, die mit einem Fehler endeten",
+                    "type": "integer",
+                    "example": 1
+                },
+                "rate_limited": {
+                    "description": "RateLimited sind 429-Antworten von TMDB",
+                    "type": "integer",
+                    "example": 0
+                },
+                "requests": {
+                    "description": "Requests sind die tatsächlich an TMDB gesendeten HTTP-Anfragen einschließlich Wiederholungen",
+                    "type": "integer",
+                    "example": 108
+                },
+                "retries": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "store_hits": {
+                    "description": "StoreHits sind Antworten aus dem zweiten Cache-Speicher (Redis)",
+                    "type": "integer",
+                    "example": 3
+                },
+                "throttled": {
+                    "description": "Throttled sind Anfragen, die auf ein Token des Limiters warten mussten",
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "tmdb.TVShow": {
+            "type": "object",
+            "properties": {
+                "credits": {
+                    "$ref": "#/definitions/tmdb.Credits"
+                },
+                "episode_run_time": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "first_air_date": {
                     "type": "string"
                 },
-                "message": {
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tmdb.Genre"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_air_date": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "number_of_episodes": {
+                    "type": "integer"
+                },
+                "number_of_seasons": {
+                    "type": "integer"
+                },
+                "original_language": {
+                    "type": "string"
+                },
+                "original_name": {
+                    "type": "string"
+                },
+                "overview": {
+                    "type": "string"
+                },
+                "poster_path": {
                     "type": "string"
+                },
+                "seasons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tmdb.Season"
+                    }
+                },
+                "vote_average": {
+                    "type": "number"
                 }
             }
         }
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/services"
+	"github.com/gin-gonic/gin"
+)
+
+type APITokenHandler struct {
+	service *services.APITokenService
+}
+
+func NewAPITokenHandler(service *services.APITokenService) *APITokenHandler {
+	return &APITokenHandler{service: service}
+}
+
+// ListTokens godoc
+// @Summary      Eigene API-Tokens auflisten
+// @Description  Gibt alle persönlichen API-Tokens des angemeldeten Benutzers ohne Klartext zurück
+// @Tags         auth
+// @Produce      json
+// @Success      200  {array}   models.APIToken
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /auth/tokens [get]
+func (h *APITokenHandler) ListTokens(c *gin.Context) {
+	tokens, err := h.service.ListTokens(auth.UserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+// CreateToken godoc
+// @Summary      API-Token anlegen
+// @Description  Legt einen persönlichen API-Token an; der Klartext wird nur in dieser Antwort zurückgegeben
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        token  body      models.APITokenRequest  true  "Name und Scope (read oder write)"
+// @Success      201  {object}  models.CreatedAPIToken
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /auth/tokens [post]
+func (h *APITokenHandler) CreateToken(c *gin.Context) {
+	var request models.APITokenRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	token, err := h.service.CreateToken(auth.UserID(c), request.Name, request.Scope)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, token)
+}
+
+// RevokeToken godoc
+// @Summary      API-Token widerrufen
+// @Description  Löscht einen persönlichen API-Token des angemeldeten Benutzers
+// @Tags         auth
+// @Produce      json
+// @Param        id   path      int  true  "Token ID"
+// @Success      200  {object}  models.SwaggerResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Router       /auth/tokens/{id} [delete]
+func (h *APITokenHandler) RevokeToken(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	if err := h.service.RevokeToken(auth.UserID(c), uint(id)); err != nil {
+		if errors.Is(err, services.ErrAPITokenNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Token revoked successfully"})
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/services"
+	"github.com/gin-gonic/gin"
+)
+
+type AuthHandler struct {
+	service *services.AuthService
+	jwt     *auth.JWTConfig
+}
+
+func NewAuthHandler(service *services.AuthService, jwtConfig *auth.JWTConfig) *AuthHandler {
+	return &AuthHandler{service: service, jwt: jwtConfig}
+}
+
+// Register godoc
+// @Summary      Benutzer registrieren
+// @Description  Legt ein neues Benutzerkonto an. Das erste Konto wird Administrator, alle weiteren starten als Bearbeiter ihrer eigenen Sammlung.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        credentials  body      models.Credentials  true  "Benutzername und Passwort"
+// @Success      201  {object}  models.User
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /auth/register [post]
+func (h *AuthHandler) Register(c *gin.Context) {
+	var credentials models.Credentials
+	if err := c.ShouldBindJSON(&credentials); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.service.Register(credentials.Username, credentials.Password)
+	if err != nil {
+		if errors.Is(err, services.ErrUsernameTaken) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, user)
+}
+
+// Login godoc
+// @Summary      Anmelden
+// @Description  Prüft die Anmeldedaten und setzt Access- und Refresh-Token als Cookies
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        credentials  body      models.Credentials  true  "Benutzername und Passwort"
+// @Success      200  {object}  models.User
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+	var credentials models.Credentials
+	if err := c.ShouldBindJSON(&credentials); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, tokens, err := h.service.Login(credentials.Username, credentials.Password)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCredentials) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.setTokenCookies(c, tokens)
+	c.JSON(http.StatusOK, user)
+}
+
+// Refresh godoc
+// @Summary      Tokens erneuern
+// @Description  Rotiert den Refresh-Token aus dem Cookie und stellt ein neues Token-Paar aus. Ein bereits verwendeter Refresh-Token beendet die gesamte Sitzung.
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  models.User
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	refreshToken, err := c.Cookie(auth.RefreshTokenCookie)
+	if err != nil || refreshToken == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Refresh-Token fehlt"})
+		return
+	}
+
+	user, tokens, err := h.service.Refresh(refreshToken)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidRefreshToken) || errors.Is(err, services.ErrRefreshTokenReused) {
+			h.clearTokenCookies(c)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.setTokenCookies(c, tokens)
+	c.JSON(http.StatusOK, user)
+}
+
+// Logout godoc
+// @Summary      Abmelden
+// @Description  Widerruft die Sitzung des Refresh-Token-Cookies und entfernt die Token-Cookies
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  models.SwaggerResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	if refreshToken, err := c.Cookie(auth.RefreshTokenCookie); err == nil && refreshToken != "" {
+		if err := h.service.Logout(refreshToken); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	h.clearTokenCookies(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
+
+// LogoutAll godoc
+// @Summary      Auf allen Geräten abmelden
+// @Description  Widerruft alle Refresh-Tokens des angemeldeten Benutzers; bestehende Access-Tokens laufen regulär ab
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  models.SwaggerResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /auth/logout-all [post]
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	if err := h.service.LogoutAll(auth.UserID(c)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.clearTokenCookies(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out on all devices"})
+}
+
+func (h *AuthHandler) setTokenCookies(c *gin.Context, tokens services.TokenPair) {
+	h.setCookie(c, auth.AccessTokenCookie, tokens.AccessToken, int(h.jwt.Expiration.Seconds()))
+	h.setCookie(c, auth.RefreshTokenCookie, tokens.RefreshToken, int(h.jwt.RefreshExpiration.Seconds()))
+}
+
+func (h *AuthHandler) clearTokenCookies(c *gin.Context) {
+	h.setCookie(c, auth.AccessTokenCookie, "", -1)
+	h.setCookie(c, auth.RefreshTokenCookie, "", -1)
+}
+
+func (h *AuthHandler) setCookie(c *gin.Context, name, value string, maxAge int) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		Secure:   h.jwt.CookieSecure,
+		HttpOnly: h.jwt.CookieHttpOnly,
+		SameSite: h.jwt.SameSite(),
+	})
+}
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/services"
+	"github.com/gin-gonic/gin"
+)
+
+type CopyHandler struct {
+	service *services.CopyService
+}
+
+func NewCopyHandler(service *services.CopyService) *CopyHandler {
+	return &CopyHandler{service: service}
+}
+
+// ListCopies godoc
+// @Summary      Exemplare eines Films auflisten
+// @Description  Gibt alle Exemplare (z. B. DVD und Blu-ray) eines Films zurück
+// @Tags         copies
+// @Produce      json
+// @Param        id   path      int  true  "Movie ID"
+// @Success      200  {array}   models.Copy
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Router       /movies/{id}/copies [get]
+func (h *CopyHandler) ListCopies(c *gin.Context) {
+	movieID, ok := parseID(c, "id", "Invalid movie ID")
+	if !ok {
+		return
+	}
+
+	copies, err := h.service.ListCopies(auth.UserID(c), movieID)
+	if err != nil {
+		respondCopyError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, copies)
+}
+
+// GetCopy godoc
+// @Summary      Einzelnes Exemplar abrufen
+// @Tags         copies
+// @Produce      json
+// @Param        id       path      int  true  "Movie ID"
+// @Param        copyId   path      int  true  "Copy ID"
+// @Success      200  {object}  models.Copy
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Router       /movies/{id}/copies/{copyId} [get]
+func (h *CopyHandler) GetCopy(c *gin.Context) {
+	movieID, ok := parseID(c, "id", "Invalid movie ID")
+	if !ok {
+		return
+	}
+	copyID, ok := parseID(c, "copyId", "Invalid copy ID")
+	if !ok {
+		return
+	}
+
+	item, err := h.service.GetCopy(auth.UserID(c), movieID, copyID)
+	if err != nil {
+		respondCopyError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, item)
+}
+
+// CreateCopy godoc
+// @Summary      Exemplar anlegen
+// @Description  Fügt einem Film ein weiteres Exemplar hinzu
+// @Tags         copies
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int          true  "Movie ID"
+// @Param        copy  body      models.Copy  true  "Exemplar"
+// @Success      201  {object}  models.Copy
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /movies/{id}/copies [post]
+func (h *CopyHandler) CreateCopy(c *gin.Context) {
+	movieID, ok := parseID(c, "id", "Invalid movie ID")
+	if !ok {
+		return
+	}
+
+	var item models.Copy
+	if err := c.ShouldBindJSON(&item); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.CreateCopy(auth.UserID(c), movieID, &item); err != nil {
+		respondCopyError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, item)
+}
+
+// UpdateCopy godoc
+// @Summary      Exemplar aktualisieren
+// @Tags         copies
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int          true  "Movie ID"
+// @Param        copyId   path      int          true  "Copy ID"
+// @Param        copy     body      models.Copy  true  "Exemplar"
+// @Success      200  {object}  models.Copy
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /movies/{id}/copies/{copyId} [put]
+func (h *CopyHandler) UpdateCopy(c *gin.Context) {
+	movieID, ok := parseID(c, "id", "Invalid movie ID")
+	if !ok {
+		return
+	}
+	copyID, ok := parseID(c, "copyId", "Invalid copy ID")
+	if !ok {
+		return
+	}
+
+	var item models.Copy
+	if err := c.ShouldBindJSON(&item); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item.ID = copyID
+	if err := h.service.UpdateCopy(auth.UserID(c), movieID, &item); err != nil {
+		respondCopyError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, item)
+}
+
+// DeleteCopy godoc
+// @Summary      Exemplar löschen
+// @Tags         copies
+// @Produce      json
+// @Param        id       path      int  true  "Movie ID"
+// @Param        copyId   path      int  true  "Copy ID"
+// @Success      200  {object}  models.SwaggerResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Router       /movies/{id}/copies/{copyId} [delete]
+func (h *CopyHandler) DeleteCopy(c *gin.Context) {
+	movieID, ok := parseID(c, "id", "Invalid movie ID")
+	if !ok {
+		return
+	}
+	copyID, ok := parseID(c, "copyId", "Invalid copy ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteCopy(auth.UserID(c), movieID, copyID); err != nil {
+		respondCopyError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Copy deleted successfully"})
+}
+
+// parseID liest einen numerischen Pfadparameter und antwortet bei ungültigen Werten mit 400
+func parseID(c *gin.Context, param, message string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(param), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func respondCopyError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrLocationNotFound) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, services.ErrMovieNotFound) || errors.Is(err, services.ErrCopyNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
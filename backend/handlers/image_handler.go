@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strconv"
 
+	"github.com/MichaelKlank/movie-collector/backend/auth"
 	"github.com/MichaelKlank/movie-collector/backend/services"
 	"github.com/gin-gonic/gin"
 )
@@ -38,7 +39,7 @@ func (h *ImageHandler) UploadImage(c *gin.Context) {
 		return
 	}
 
-	movie, err := h.service.GetMovieByID(uint(id))
+	movie, err := h.service.GetMovieByID(auth.UserID(c), uint(id))
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
 		return
@@ -67,7 +68,7 @@ func (h *ImageHandler) UploadImage(c *gin.Context) {
 
 	// Update movie with image path
 	movie.ImagePath = filename
-	if err := h.service.UpdateMovie(&movie); err != nil {
+	if err := h.service.UpdateMovie(c.Request.Context(), auth.UserID(c), &movie); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update movie with image path"})
 		return
 	}
@@ -92,7 +93,7 @@ func (h *ImageHandler) GetImage(c *gin.Context) {
 		return
 	}
 
-	movie, err := h.service.GetMovieByID(uint(id))
+	movie, err := h.service.GetMovieByID(auth.UserID(c), uint(id))
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
 		return
@@ -130,7 +131,7 @@ func (h *ImageHandler) DeleteImage(c *gin.Context) {
 		return
 	}
 
-	movie, err := h.service.GetMovieByID(uint(id))
+	movie, err := h.service.GetMovieByID(auth.UserID(c), uint(id))
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
 		return
@@ -149,7 +150,7 @@ func (h *ImageHandler) DeleteImage(c *gin.Context) {
 
 	// Update movie to remove image path
 	movie.ImagePath = ""
-	if err := h.service.UpdateMovie(&movie); err != nil {
+	if err := h.service.UpdateMovie(c.Request.Context(), auth.UserID(c), &movie); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update movie"})
 		return
 	}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/services"
+	"github.com/gin-gonic/gin"
+)
+
+type LoanHandler struct {
+	service *services.LoanService
+}
+
+func NewLoanHandler(service *services.LoanService) *LoanHandler {
+	return &LoanHandler{service: service}
+}
+
+// LendMovie godoc
+// @Summary      Film verleihen
+// @Description  Verleiht einen Film; ohne lent_on gilt das heutige Datum
+// @Tags         loans
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                 true  "Movie ID"
+// @Param        loan  body      models.LoanRequest  true  "Ausleihe"
+// @Success      201  {object}  models.Loan
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /movies/{id}/loans [post]
+func (h *LoanHandler) LendMovie(c *gin.Context) {
+	movieID, ok := parseID(c, "id", "Invalid movie ID")
+	if !ok {
+		return
+	}
+
+	var request models.LoanRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	loan, err := h.service.Lend(auth.UserID(c), movieID, request)
+	if err != nil {
+		respondLoanError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, loan)
+}
+
+// ReturnMovie godoc
+// @Summary      Film zurückgeben
+// @Description  Beendet die laufende Ausleihe; ohne returned_on gilt das heutige Datum
+// @Tags         loans
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                   true   "Movie ID"
+// @Param        return  body      models.ReturnRequest  false  "Rückgabe"
+// @Success      200  {object}  models.Loan
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /movies/{id}/return [post]
+func (h *LoanHandler) ReturnMovie(c *gin.Context) {
+	movieID, ok := parseID(c, "id", "Invalid movie ID")
+	if !ok {
+		return
+	}
+
+	var request models.ReturnRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	loan, err := h.service.Return(auth.UserID(c), movieID, request)
+	if err != nil {
+		respondLoanError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, loan)
+}
+
+// GetMovieLoans godoc
+// @Summary      Ausleihhistorie eines Films
+// @Tags         loans
+// @Produce      json
+// @Param        id   path      int  true  "Movie ID"
+// @Success      200  {array}   models.Loan
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Router       /movies/{id}/loans [get]
+func (h *LoanHandler) GetMovieLoans(c *gin.Context) {
+	movieID, ok := parseID(c, "id", "Invalid movie ID")
+	if !ok {
+		return
+	}
+
+	loans, err := h.service.History(auth.UserID(c), movieID)
+	if err != nil {
+		respondLoanError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, loans)
+}
+
+// ListActiveLoans godoc
+// @Summary      Verliehene Filme auflisten
+// @Description  Gibt alle laufenden Ausleihen nach Fälligkeit sortiert zurück
+// @Tags         loans
+// @Produce      json
+// @Success      200  {array}   models.Loan
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /loans [get]
+func (h *LoanHandler) ListActiveLoans(c *gin.Context) {
+	loans, err := h.service.ListActive(auth.UserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, loans)
+}
+
+// ListOverdueLoans godoc
+// @Summary      Überfällige Ausleihen auflisten
+// @Description  Gibt alle laufenden Ausleihen zurück, deren Fälligkeitsdatum überschritten ist
+// @Tags         loans
+// @Produce      json
+// @Success      200  {array}   models.Loan
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /loans/overdue [get]
+func (h *LoanHandler) ListOverdueLoans(c *gin.Context) {
+	loans, err := h.service.ListOverdue(auth.UserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, loans)
+}
+
+func respondLoanError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrMovieNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAlreadyLent), errors.Is(err, services.ErrNotLent), errors.Is(err, services.ErrNotInCollection):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidLoanDates):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
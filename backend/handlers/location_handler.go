@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/services"
+	"github.com/gin-gonic/gin"
+)
+
+type LocationHandler struct {
+	service *services.LocationService
+}
+
+func NewLocationHandler(service *services.LocationService) *LocationHandler {
+	return &LocationHandler{service: service}
+}
+
+// ListLocations godoc
+// @Summary      Lagerorte auflisten
+// @Description  Gibt alle Lagerorte der eigenen Sammlung sortiert nach Raum, Regal und Fach zurück
+// @Tags         locations
+// @Produce      json
+// @Success      200  {array}   models.Location
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /locations [get]
+func (h *LocationHandler) ListLocations(c *gin.Context) {
+	locations, err := h.service.ListLocations(auth.UserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, locations)
+}
+
+// GetLocation godoc
+// @Summary      Einzelnen Lagerort abrufen
+// @Tags         locations
+// @Produce      json
+// @Param        id   path      int  true  "Location ID"
+// @Success      200  {object}  models.Location
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Router       /locations/{id} [get]
+func (h *LocationHandler) GetLocation(c *gin.Context) {
+	id, ok := parseID(c, "id", "Invalid location ID")
+	if !ok {
+		return
+	}
+
+	location, err := h.service.GetLocation(auth.UserID(c), id)
+	if err != nil {
+		respondLocationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, location)
+}
+
+// CreateLocation godoc
+// @Summary      Lagerort anlegen
+// @Tags         locations
+// @Accept       json
+// @Produce      json
+// @Param        location  body      models.Location  true  "Raum, Regal und Fach"
+// @Success      201  {object}  models.Location
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /locations [post]
+func (h *LocationHandler) CreateLocation(c *gin.Context) {
+	var location models.Location
+	if err := c.ShouldBindJSON(&location); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.CreateLocation(auth.UserID(c), &location); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, location)
+}
+
+// UpdateLocation godoc
+// @Summary      Lagerort aktualisieren
+// @Tags         locations
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int              true  "Location ID"
+// @Param        location  body      models.Location  true  "Raum, Regal und Fach"
+// @Success      200  {object}  models.Location
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /locations/{id} [put]
+func (h *LocationHandler) UpdateLocation(c *gin.Context) {
+	id, ok := parseID(c, "id", "Invalid location ID")
+	if !ok {
+		return
+	}
+
+	var location models.Location
+	if err := c.ShouldBindJSON(&location); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	location.ID = id
+	if err := h.service.UpdateLocation(auth.UserID(c), &location); err != nil {
+		respondLocationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, location)
+}
+
+// DeleteLocation godoc
+// @Summary      Lagerort löschen
+// @Description  Löscht einen Lagerort; zugeordnete Filme und Exemplare verlieren ihre Zuordnung
+// @Tags         locations
+// @Produce      json
+// @Param        id   path      int  true  "Location ID"
+// @Success      200  {object}  models.SwaggerResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Router       /locations/{id} [delete]
+func (h *LocationHandler) DeleteLocation(c *gin.Context) {
+	id, ok := parseID(c, "id", "Invalid location ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteLocation(auth.UserID(c), id); err != nil {
+		respondLocationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Location deleted successfully"})
+}
+
+// GetLocationMovies godoc
+// @Summary      Filme an einem Lagerort
+// @Description  Gibt die Filme zurück, die selbst oder mit einem Exemplar an diesem Lagerort stehen
+// @Tags         locations
+// @Produce      json
+// @Param        id      path    int  true   "Location ID"
+// @Param        page    query   int  false  "Seitennummer (Standard: 1)"
+// @Param        limit   query   int  false  "Einträge pro Seite (Standard: 20, Max: 100)"
+// @Success      200  {object}  models.PaginatedResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /locations/{id}/movies [get]
+func (h *LocationHandler) GetLocationMovies(c *gin.Context) {
+	id, ok := parseID(c, "id", "Invalid location ID")
+	if !ok {
+		return
+	}
+
+	page, limit, offset := parsePagination(c)
+	movies, total, err := h.service.GetMoviesAtLocation(auth.UserID(c), id, offset, limit)
+	if err != nil {
+		respondLocationError(c, err)
+		return
+	}
+	respondPaginated(c, movies, total, page, limit)
+}
+
+func respondLocationError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrLocationNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
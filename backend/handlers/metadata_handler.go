@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/services"
+	"github.com/gin-gonic/gin"
+)
+
+type MetadataHandler struct {
+	service *services.MetadataService
+}
+
+func NewMetadataHandler(service *services.MetadataService) *MetadataHandler {
+	return &MetadataHandler{service: service}
+}
+
+// SyncMovieMetadata godoc
+// @Summary      TMDB-Metadaten übernehmen
+// @Description  Lädt Genres, Laufzeit, Besetzung und Stab eines mit TMDB verknüpften Films und speichert sie lokal
+// @Tags         movies
+// @Produce      json
+// @Param        id   path      int  true  "Movie ID"
+// @Success      200  {object}  models.Movie
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      429  {object}  models.ErrorResponse
+// @Failure      502  {object}  models.ErrorResponse
+// @Failure      503  {object}  models.ErrorResponse
+// @Failure      504  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /movies/{id}/metadata [post]
+func (h *MetadataHandler) SyncMovieMetadata(c *gin.Context) {
+	id, ok := parseID(c, "id", "Invalid movie ID")
+	if !ok {
+		return
+	}
+
+	movie, err := h.service.SyncMovie(c.Request.Context(), auth.UserID(c), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrMovieNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrNotLinkedToTMDB):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrTMDBRequest):
+			RespondTMDBError(c, err)
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, movie)
+}
+
+// GetPersonMovies godoc
+// @Summary      Filme einer Person
+// @Description  Gibt die Filme der Sammlung zurück, in denen eine Person mitspielt oder im Stab mitwirkt
+// @Tags         people
+// @Produce      json
+// @Param        id      path    int     true   "Person ID"
+// @Param        kind    query   string  false  "Nur Besetzung oder nur Stab"  Enums(cast, crew)
+// @Param        job     query   string  false  "Aufgabe im Stab, z. B. Director"
+// @Param        status  query   string  false  "Status (Standard: owned)"  Enums(owned, wishlist, preordered, all)
+// @Param        page    query   int     false  "Seitennummer (Standard: 1)"
+// @Param        limit   query   int     false  "Einträge pro Seite (Standard: 20, Max: 100)"
+// @Success      200  {object}  models.PaginatedResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /people/{id}/movies [get]
+func (h *MetadataHandler) GetPersonMovies(c *gin.Context) {
+	id, ok := parseID(c, "id", "Invalid person ID")
+	if !ok {
+		return
+	}
+
+	kind := c.Query("kind")
+	if kind != "" && kind != models.CreditCast && kind != models.CreditCrew {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid credit kind"})
+		return
+	}
+
+	filter, ok := browseFilter(c)
+	if !ok {
+		return
+	}
+
+	page, limit, offset := parsePagination(c)
+	movies, total, err := h.service.GetPersonMovies(auth.UserID(c), id, kind, c.Query("job"), filter, offset, limit)
+	if err != nil {
+		respondMetadataError(c, err)
+		return
+	}
+	respondPaginated(c, movies, total, page, limit)
+}
+
+// ListGenres godoc
+// @Summary      Genres auflisten
+// @Description  Gibt alle Genres der Sammlung mit der Anzahl ihrer Filme zurück
+// @Tags         genres
+// @Produce      json
+// @Param        status  query   string  false  "Status (Standard: owned)"  Enums(owned, wishlist, preordered, all)
+// @Success      200  {object}  models.GenreListResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /genres [get]
+func (h *MetadataHandler) ListGenres(c *gin.Context) {
+	filter, ok := browseFilter(c)
+	if !ok {
+		return
+	}
+
+	genres, err := h.service.ListGenres(auth.UserID(c), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": genres,
+		"meta": gin.H{
+			"total": len(genres),
+		},
+	})
+}
+
+// GetGenreMovies godoc
+// @Summary      Filme eines Genres
+// @Tags         genres
+// @Produce      json
+// @Param        id      path    int     true   "Genre ID"
+// @Param        status  query   string  false  "Status (Standard: owned)"  Enums(owned, wishlist, preordered, all)
+// @Param        page    query   int     false  "Seitennummer (Standard: 1)"
+// @Param        limit   query   int     false  "Einträge pro Seite (Standard: 20, Max: 100)"
+// @Success      200  {object}  models.PaginatedResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /genres/{id}/movies [get]
+func (h *MetadataHandler) GetGenreMovies(c *gin.Context) {
+	id, ok := parseID(c, "id", "Invalid genre ID")
+	if !ok {
+		return
+	}
+
+	filter, ok := browseFilter(c)
+	if !ok {
+		return
+	}
+
+	page, limit, offset := parsePagination(c)
+	movies, total, err := h.service.GetGenreMovies(auth.UserID(c), id, filter, offset, limit)
+	if err != nil {
+		respondMetadataError(c, err)
+		return
+	}
+	respondPaginated(c, movies, total, page, limit)
+}
+
+// browseFilter liest die Filmfilter; wie die Hauptliste zeigen die Übersichten standardmäßig nur den Bestand
+func browseFilter(c *gin.Context) (models.MovieFilter, bool) {
+	filter, ok := bindMovieFilter(c)
+	if !ok {
+		return filter, false
+	}
+	if filter.Status == "" {
+		filter.Status = models.StatusOwned
+	}
+	return filter, true
+}
+
+func respondMetadataError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrPersonNotFound) || errors.Is(err, services.ErrGenreNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
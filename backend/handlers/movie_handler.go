@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/MichaelKlank/movie-collector/backend/auth"
 	"github.com/MichaelKlank/movie-collector/backend/models"
 	"github.com/MichaelKlank/movie-collector/backend/services"
 	"github.com/gin-gonic/gin"
@@ -19,101 +22,205 @@ func NewMovieHandler(service *services.MovieService) *MovieHandler {
 
 // GetMovies godoc
 // @Summary      Liste aller Filme abrufen
-// @Description  Gibt eine paginierte Liste aller gespeicherten Filme zurück
+// @Description  Gibt eine sortierte, paginierte Liste der Filme im Bestand samt Facetten (Jahrzehnte, Bewertungsbereiche) zurück; Wunschliste und Vorbestellungen über status
 // @Tags         movies
 // @Accept       json
 // @Produce      json
 // @Param        page    query   int  false  "Seitennummer (Standard: 1)"
 // @Param        limit   query   int  false  "Einträge pro Seite (Standard: 20, Max: 100)"
+// @Param        cursor         query   string  false  "Keyset-Paginierung: leer für die erste Seite, danach next_cursor bzw. prev_cursor aus meta; ersetzt page"
+// @Param        include_total  query   bool    false  "Gesamtanzahl und Facetten zählen (Standard: true); false spart die Zählabfragen"
+// @Param        sort        query   string  false  "Sortierung (Standard: title)"  Enums(title, year, rating, created_at, updated_at)
+// @Param        order       query   string  false  "Richtung (Standard: asc)"  Enums(asc, desc)
+// @Param        format      query   string  false  "Medienformat"  Enums(dvd, bluray, uhd, digital)
+// @Param        region      query   string  false  "Regionalcode (0-8 für DVD, A/B/C für Blu-ray, free)"
+// @Param        edition     query   string  false  "Teil des Editionsnamens, z. B. Steelbook"
+// @Param        disc_count  query   int     false  "Anzahl der Discs"
+// @Param        status      query   string  false  "Status (Standard: owned)"  Enums(owned, wishlist, preordered, all)
+// @Param        year_from   query   int     false  "Erscheinungsjahr ab"
+// @Param        year_to     query   int     false  "Erscheinungsjahr bis"
+// @Param        rating_min  query   number  false  "Mindestbewertung (0-10)"
+// @Param        has_image   query   bool    false  "Nur Filme mit (true) oder ohne (false) Bild oder Poster"
+// @Param        tmdb_linked query   bool    false  "Nur Filme mit (true) oder ohne (false) TMDB-Verknüpfung"
+// @Param        starts_with query   string  false  "Anfangsbuchstabe ohne Artikel (A-Z) oder # für Ziffern und Zeichen"
 // @Success      200  {object}  models.PaginatedResponse
+// @Failure      400  {object}  models.ErrorResponse
 // @Failure      500  {object}  models.ErrorResponse
 // @Router       /movies [get]
 func (h *MovieHandler) GetMovies(c *gin.Context) {
-	// Parameter für Paginierung
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	if page < 1 {
-		page = 1
+	filter, ok := bindMovieFilter(c)
+	if !ok {
+		return
 	}
 
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
-	if limit < 1 {
-		limit = 20
-	} else if limit > 100 {
-		limit = 100 // Maximale Anzahl begrenzen
+	request, page, ok := parsePageRequest(c, filter)
+	if !ok {
+		return
 	}
 
-	offset := (page - 1) * limit
+	// Die Hauptliste zeigt nur den Bestand
+	if filter.Status == "" {
+		filter.Status = models.StatusOwned
+	}
 
-	movies, total, err := h.service.GetMoviesPaginated(offset, limit)
+	movies, info, err := h.service.GetMoviesPaginated(auth.UserID(c), filter, request)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Berechne Gesamtanzahl der Seiten
-	totalPages := (total + int64(limit) - 1) / int64(limit)
+	meta := pageMeta(request, info, page)
+
+	// Die Facetten zählen wie die Gesamtanzahl über alle Treffer und entfallen mit ihr
+	if !request.SkipTotal {
+		facets, err := h.service.GetFacets(auth.UserID(c), filter)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		meta["facets"] = facets
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"data": movies,
+		"meta": meta,
+	})
+}
+
+// GetMovieIndex godoc
+// @Summary      Alphabet-Index der Filmliste
+// @Description  Gibt für jeden Anfangsbuchstaben der nach Titel sortierten Liste die Anzahl der Filme und die Seite bzw. den Cursor zurück, an dem der erste davon steht. Führende Artikel (The, Der, Die, Das) werden übersprungen, Ziffern und Zeichen unter "#" zusammengefasst. Es gelten dieselben Filter wie bei GET /movies.
+// @Tags         movies
+// @Accept       json
+// @Produce      json
+// @Param        limit   query   int     false  "Einträge pro Seite, auf die sich page bezieht (Standard: 20, Max: 100)"
+// @Param        order   query   string  false  "Richtung der Titelsortierung (Standard: asc)"  Enums(asc, desc)
+// @Param        format      query   string  false  "Medienformat"  Enums(dvd, bluray, uhd, digital)
+// @Param        region      query   string  false  "Regionalcode (0-8 für DVD, A/B/C für Blu-ray, free)"
+// @Param        edition     query   string  false  "Teil des Editionsnamens, z. B. Steelbook"
+// @Param        disc_count  query   int     false  "Anzahl der Discs"
+// @Param        status      query   string  false  "Status (Standard: owned)"  Enums(owned, wishlist, preordered, all)
+// @Param        year_from   query   int     false  "Erscheinungsjahr ab"
+// @Param        year_to     query   int     false  "Erscheinungsjahr bis"
+// @Param        rating_min  query   number  false  "Mindestbewertung (0-10)"
+// @Param        has_image   query   bool    false  "Nur Filme mit (true) oder ohne (false) Bild oder Poster"
+// @Param        tmdb_linked query   bool    false  "Nur Filme mit (true) oder ohne (false) TMDB-Verknüpfung"
+// @Success      200  {object}  models.AlphabetIndexResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /movies/index [get]
+func (h *MovieHandler) GetMovieIndex(c *gin.Context) {
+	_, limit, _ := parsePagination(c)
+
+	filter, ok := bindMovieFilter(c)
+	if !ok {
+		return
+	}
+	if filter.Sort != "" && filter.Sort != models.SortTitle {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The alphabet index requires sort=title"})
+		return
+	}
+
+	// Wie die Hauptliste zeigt der Index nur den Bestand
+	if filter.Status == "" {
+		filter.Status = models.StatusOwned
+	}
+
+	entries, total, err := h.service.GetAlphabetIndex(auth.UserID(c), filter, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": entries,
 		"meta": gin.H{
-			"page":        page,
-			"limit":       limit,
-			"total":       total,
-			"total_pages": totalPages,
+			"limit": limit,
+			"total": total,
 		},
 	})
 }
 
 // SearchMovies godoc
 // @Summary      Filme suchen
-// @Description  Durchsucht die Filmdatenbank nach einem Suchbegriff
+// @Description  Durchsucht die Filmdatenbank nach einem Suchbegriff. q unterstützt eine Suchsprache mit den Feldern year, rating, runtime, discs (Zahl, >7, <=2000, 1990..1999), format, region, status, edition, title, genre, director und actor (Wert oder "in Anführungszeichen"); ein führendes "-" negiert. Syntaxfehler liefern 400 mit position. Mit fuzzy=true werden Titel tippfehlertolerant verglichen und jeder Treffer enthält eine Ähnlichkeit (score, FuzzySearchResponse).
 // @Tags         movies
 // @Accept       json
 // @Produce      json
-// @Param        q       query   string  true   "Suchbegriff"
+// @Param        q       query   string  true   "Suchbegriff, z. B. year:1990..1999 rating:>7 format:bluray director:\"Ridley Scott\" -horror"
+// @Param        fuzzy   query   bool    false  "Tippfehlertolerante Titelsuche mit Ähnlichkeitswert"
 // @Param        page    query   int     false  "Seitennummer (Standard: 1)"
 // @Param        limit   query   int     false  "Einträge pro Seite (Standard: 20, Max: 100)"
+// @Param        cursor         query   string  false  "Keyset-Paginierung: leer für die erste Seite, danach next_cursor bzw. prev_cursor aus meta; nicht mit fuzzy"
+// @Param        include_total  query   bool    false  "Gesamtanzahl zählen (Standard: true)"
+// @Param        format      query   string  false  "Medienformat"  Enums(dvd, bluray, uhd, digital)
+// @Param        region      query   string  false  "Regionalcode (0-8 für DVD, A/B/C für Blu-ray, free)"
+// @Param        edition     query   string  false  "Teil des Editionsnamens, z. B. Steelbook"
+// @Param        disc_count  query   int     false  "Anzahl der Discs"
+// @Param        status      query   string  false  "Status"  Enums(owned, wishlist, preordered, all)
+// @Param        year_from   query   int     false  "Erscheinungsjahr ab"
+// @Param        year_to     query   int     false  "Erscheinungsjahr bis"
+// @Param        rating_min  query   number  false  "Mindestbewertung (0-10)"
+// @Param        has_image   query   bool    false  "Nur Filme mit (true) oder ohne (false) Bild oder Poster"
+// @Param        tmdb_linked query   bool    false  "Nur Filme mit (true) oder ohne (false) TMDB-Verknüpfung"
+// @Param        sort        query   string  false  "Sortierung (Standard: title)"  Enums(title, year, rating, created_at, updated_at)
+// @Param        order       query   string  false  "Richtung (Standard: asc)"  Enums(asc, desc)
 // @Success      200  {object}  models.PaginatedResponse
+// @Failure      400  {object}  models.QueryErrorResponse
 // @Failure      500  {object}  models.ErrorResponse
 // @Router       /movies/search [get]
 func (h *MovieHandler) SearchMovies(c *gin.Context) {
 	// Hole den Suchbegriff
 	query := c.Query("q")
 
-	// Parameter für Paginierung
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	if page < 1 {
-		page = 1
+	filter, ok := bindMovieFilter(c)
+	if !ok {
+		return
 	}
 
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
-	if limit < 1 {
-		limit = 20
-	} else if limit > 100 {
-		limit = 100 // Maximale Anzahl begrenzen
+	request, page, ok := parsePageRequest(c, filter)
+	if !ok {
+		return
 	}
 
-	offset := (page - 1) * limit
+	fuzzy, err := strconv.ParseBool(c.DefaultQuery("fuzzy", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid fuzzy parameter"})
+		return
+	}
 
-	// Führe die Suche durch
-	movies, total, err := h.service.SearchMovies(query, offset, limit)
+	// Führe die Suche durch; die unscharfe Suche ordnet nach Ähnlichkeit und blättert daher nur per Offset
+	var data interface{}
+	info := models.PageInfo{}
+	if fuzzy {
+		if request.Cursor != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Cursor pagination is not supported for fuzzy search"})
+			return
+		}
+		request.SkipTotal = false
+		data, info.Total, err = h.service.FuzzySearchMovies(auth.UserID(c), query, filter, request.Offset, request.Limit)
+	} else {
+		data, info, err = h.service.SearchMovies(auth.UserID(c), query, filter, request)
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		var syntaxErr *models.QuerySyntaxError
+		switch {
+		case errors.As(err, &syntaxErr):
+			c.JSON(http.StatusBadRequest, gin.H{"error": syntaxErr.Error(), "position": syntaxErr.Position})
+		case errors.Is(err, services.ErrFuzzyWithoutText):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
-	// Berechne Gesamtanzahl der Seiten
-	totalPages := (total + int64(limit) - 1) / int64(limit)
+	meta := pageMeta(request, info, page)
+	meta["query"] = query
 
 	c.JSON(http.StatusOK, gin.H{
-		"data": movies,
-		"meta": gin.H{
-			"page":        page,
-			"limit":       limit,
-			"total":       total,
-			"total_pages": totalPages,
-			"query":       query,
-		},
+		"data": data,
+		"meta": meta,
 	})
 }
 
@@ -135,7 +242,7 @@ func (h *MovieHandler) GetMovie(c *gin.Context) {
 		return
 	}
 
-	movie, err := h.service.GetMovieByID(uint(id))
+	movie, err := h.service.GetMovieByID(auth.UserID(c), uint(id))
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
 		return
@@ -145,7 +252,7 @@ func (h *MovieHandler) GetMovie(c *gin.Context) {
 
 // CreateMovie godoc
 // @Summary      Neuen Film erstellen
-// @Description  Erstellt einen neuen Film in der Datenbank
+// @Description  Erstellt einen neuen Film in der Datenbank; mit tmdb_id werden Genres, Laufzeit und Credits aus TMDB übernommen, sofern ein TMDB-Schlüssel konfiguriert ist. Schlägt das fehl, bleibt der Film ohne Metadaten gespeichert.
 // @Tags         movies
 // @Accept       json
 // @Produce      json
@@ -161,9 +268,13 @@ func (h *MovieHandler) CreateMovie(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.CreateMovie(&movie); err != nil {
-		if err.Error() == "Ein Film mit dieser TMDB-ID existiert bereits" {
-			existingMovie, _ := h.service.GetMovieByTMDBID(movie.TMDBId)
+	if err := h.service.CreateMovie(c.Request.Context(), auth.UserID(c), &movie); err != nil {
+		if errors.Is(err, models.ErrInvalidMedia) || errors.Is(err, services.ErrLocationNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrDuplicateTMDBID) {
+			existingMovie, _ := h.service.FindDuplicate(auth.UserID(c), &movie)
 			c.JSON(http.StatusConflict, gin.H{
 				"error": err.Error(),
 				"movie": existingMovie,
@@ -179,7 +290,7 @@ func (h *MovieHandler) CreateMovie(c *gin.Context) {
 
 // UpdateMovie godoc
 // @Summary      Film aktualisieren
-// @Description  Aktualisiert einen bestehenden Film
+// @Description  Aktualisiert einen bestehenden Film; eine neue tmdb_id übernimmt wie beim Anlegen Genres, Laufzeit und Credits aus TMDB
 // @Tags         movies
 // @Accept       json
 // @Produce      json
@@ -188,6 +299,7 @@ func (h *MovieHandler) CreateMovie(c *gin.Context) {
 // @Success      200   {object}  models.Movie
 // @Failure      400   {object}  models.ErrorResponse
 // @Failure      404   {object}  models.ErrorResponse
+// @Failure      409   {object}  models.ErrorResponse
 // @Failure      500   {object}  models.ErrorResponse
 // @Router       /movies/{id} [put]
 func (h *MovieHandler) UpdateMovie(c *gin.Context) {
@@ -204,7 +316,23 @@ func (h *MovieHandler) UpdateMovie(c *gin.Context) {
 	}
 
 	movie.ID = uint(id)
-	if err := h.service.UpdateMovie(&movie); err != nil {
+	if err := h.service.UpdateMovie(c.Request.Context(), auth.UserID(c), &movie); err != nil {
+		if errors.Is(err, models.ErrInvalidMedia) || errors.Is(err, services.ErrLocationNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "Movie not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Film nicht gefunden"})
+			return
+		}
+		if errors.Is(err, services.ErrDuplicateTMDBID) {
+			existingMovie, _ := h.service.FindDuplicate(auth.UserID(c), &movie)
+			c.JSON(http.StatusConflict, gin.H{
+				"error": err.Error(),
+				"movie": existingMovie,
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -231,7 +359,7 @@ func (h *MovieHandler) DeleteMovie(c *gin.Context) {
 		return
 	}
 
-	err = h.service.DeleteMovie(uint(id))
+	err = h.service.DeleteMovie(auth.UserID(c), uint(id))
 	if err != nil {
 		if err.Error() == "Movie not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Film nicht gefunden"})
@@ -243,3 +371,59 @@ func (h *MovieHandler) DeleteMovie(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Movie deleted successfully"})
 }
+
+// AcquireMovie godoc
+// @Summary      Film in den Bestand übernehmen
+// @Description  Übernimmt einen Film von der Wunschliste oder aus einer Vorbestellung in den Bestand; TMDB-Daten bleiben erhalten
+// @Tags         movies
+// @Produce      json
+// @Param        id   path      int  true  "Movie ID"
+// @Success      200  {object}  models.Movie
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /movies/{id}/acquire [post]
+func (h *MovieHandler) AcquireMovie(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid movie ID"})
+		return
+	}
+
+	movie, err := h.service.AcquireMovie(auth.UserID(c), uint(id))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrMovieNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrAlreadyOwned):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, movie)
+}
+
+// bindMovieFilter liest Filter und Sortierung aus der Query und antwortet bei ungültigen Werten mit 400
+func bindMovieFilter(c *gin.Context) (models.MovieFilter, bool) {
+	var filter models.MovieFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return filter, false
+	}
+	if filter.YearFrom > 0 && filter.YearTo > 0 && filter.YearFrom > filter.YearTo {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "year_from must not be greater than year_to"})
+		return filter, false
+	}
+	if filter.StartsWith != "" {
+		filter.StartsWith = strings.ToUpper(filter.StartsWith)
+		if filter.StartsWith != models.IndexOther && (filter.StartsWith < "A" || filter.StartsWith > "Z") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "starts_with must be a letter A-Z or #"})
+			return filter, false
+		}
+	}
+	return filter, true
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/gin-gonic/gin"
+)
+
+// parsePagination liest page und limit (Standard 1 und 20, höchstens 100 Einträge) und berechnet den Offset
+func parsePagination(c *gin.Context) (page, limit, offset int) {
+	page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+
+	limit, _ = strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit < 1 {
+		limit = 20
+	} else if limit > 100 {
+		limit = 100 // Maximale Anzahl begrenzen
+	}
+
+	return page, limit, (page - 1) * limit
+}
+
+// parsePageRequest liest zusätzlich zu page und limit die Parameter cursor und include_total
+// Ist cursor angegeben (auch leer für die erste Seite), wird per Keyset statt per Offset geblättert;
+// der Cursor muss zur Sortierung des Filters passen. Bei ungültigen Werten antwortet sie mit 400 und liefert false.
+func parsePageRequest(c *gin.Context, filter models.MovieFilter) (request models.PageRequest, page int, ok bool) {
+	page, request.Limit, request.Offset = parsePagination(c)
+
+	includeTotal, err := strconv.ParseBool(c.DefaultQuery("include_total", "true"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid include_total parameter"})
+		return request, page, false
+	}
+	request.SkipTotal = !includeTotal
+
+	if encoded, found := c.GetQuery("cursor"); found {
+		cursor, err := models.ParseCursor(encoded, filter)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return request, page, false
+		}
+		request.Cursor = cursor
+		request.Offset = 0
+	}
+	return request, page, true
+}
+
+// pageMeta baut die Metadaten einer Seite: page und total_pages beim Blättern per Offset,
+// next_cursor und prev_cursor im Cursor-Modus; total nur, wenn gezählt wurde
+func pageMeta(request models.PageRequest, info models.PageInfo, page int) gin.H {
+	meta := gin.H{"limit": request.Limit}
+	if request.Cursor != nil {
+		if info.NextCursor != "" {
+			meta["next_cursor"] = info.NextCursor
+		}
+		if info.PrevCursor != "" {
+			meta["prev_cursor"] = info.PrevCursor
+		}
+	} else {
+		meta["page"] = page
+	}
+	if !request.SkipTotal {
+		meta["total"] = info.Total
+		if request.Cursor == nil {
+			meta["total_pages"] = (info.Total + int64(request.Limit) - 1) / int64(request.Limit)
+		}
+	}
+	return meta
+}
+
+// respondPaginated antwortet mit dem Umschlag {data, meta} von GET /movies
+func respondPaginated(c *gin.Context, movies []models.Movie, total int64, page, limit int) {
+	totalPages := (total + int64(limit) - 1) / int64(limit)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": movies,
+		"meta": gin.H{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
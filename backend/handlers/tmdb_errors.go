@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/MichaelKlank/movie-collector/backend/tmdb"
+	"github.com/gin-gonic/gin"
+)
+
+// RespondTMDBError antwortet auf einen Fehler des TMDB-Clients mit einem passenden Status
+// Ein abgelehnter API-Schlüssel ist ein Konfigurationsfehler des Servers und wird daher als 502
+// statt 401 gemeldet, damit Clients ihn nicht als abgelaufene Anmeldung deuten.
+func RespondTMDBError(c *gin.Context, err error) {
+	status := http.StatusBadGateway
+	switch {
+	case errors.Is(err, tmdb.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, tmdb.ErrRateLimited):
+		status = http.StatusTooManyRequests
+		if wait := tmdb.RetryAfter(err); wait > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		}
+	case errors.Is(err, context.DeadlineExceeded):
+		status = http.StatusGatewayTimeout
+	case errors.Is(err, tmdb.ErrUnavailable):
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/MichaelKlank/movie-collector/backend/tmdb"
+	"github.com/gin-gonic/gin"
+)
+
+type TMDBHandler struct {
+	client *tmdb.Client
+}
+
+func NewTMDBHandler(client *tmdb.Client) *TMDBHandler {
+	return &TMDBHandler{client: client}
+}
+
+// SearchMovies godoc
+// @Summary      Filme und Serien bei TMDB suchen
+// @Description  Sucht Filme (type=movie, Standard), Serien (type=tv) oder beides (type=multi) bei TMDB nach Titel. Serien und Treffer der Mehrfachsuche enthalten media_type. Ohne Angabe gelten Sprache und Region aus TMDB_LANGUAGE und TMDB_REGION; fehlt eine übersetzte Beschreibung, wird die englische geliefert. Fehler von TMDB werden als 404, 429 (mit Retry-After), 502, 503 oder 504 gemeldet.
+// @Tags         tmdb
+// @Produce      json
+// @Param        query     query     string  true   "Suchbegriff"
+// @Param        type      query     string  false  "Art der Suche" Enums(movie, tv, multi)
+// @Param        language  query     string  false  "Sprache der Metadaten, z. B. de-DE"
+// @Param        region    query     string  false  "Region nach ISO 3166-1, z. B. DE"
+// @Success      200  {array}   tmdb.Movie
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      429  {object}  models.ErrorResponse
+// @Failure      502  {object}  models.ErrorResponse
+// @Failure      503  {object}  models.ErrorResponse
+// @Failure      504  {object}  models.ErrorResponse
+// @Router       /tmdb/search [get]
+func (h *TMDBHandler) SearchMovies(c *gin.Context) {
+	query := c.Query("query")
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "query parameter is required"})
+		return
+	}
+
+	locale, ok := h.locale(c)
+	if !ok {
+		return
+	}
+
+	var movies []tmdb.Movie
+	var err error
+	switch c.DefaultQuery("type", tmdb.MediaTypeMovie) {
+	case tmdb.MediaTypeMovie:
+		movies, err = h.client.SearchMoviesLocalized(c.Request.Context(), query, locale)
+	case tmdb.MediaTypeTV:
+		movies, err = h.client.SearchTVLocalized(c.Request.Context(), query, locale)
+	case "multi":
+		movies, err = h.client.SearchMultiLocalized(c.Request.Context(), query, locale)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "type must be movie, tv or multi"})
+		return
+	}
+	if err != nil {
+		RespondTMDBError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, movies)
+}
+
+// GetMovie godoc
+// @Summary      TMDB-Filmdetails abrufen
+// @Description  Lädt die Details eines Films bei TMDB einschließlich Genres, Laufzeit, Credits und Originaltitel. Fehlt eine übersetzte Beschreibung, wird die englische geliefert.
+// @Tags         tmdb
+// @Produce      json
+// @Param        id        path      int     true   "TMDB-ID"
+// @Param        language  query     string  false  "Sprache der Metadaten, z. B. de-DE"
+// @Success      200  {object}  tmdb.Movie
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      429  {object}  models.ErrorResponse
+// @Failure      502  {object}  models.ErrorResponse
+// @Failure      503  {object}  models.ErrorResponse
+// @Failure      504  {object}  models.ErrorResponse
+// @Router       /tmdb/movie/{id} [get]
+func (h *TMDBHandler) GetMovie(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid movie ID"})
+		return
+	}
+
+	locale, ok := h.locale(c)
+	if !ok {
+		return
+	}
+
+	movie, err := h.client.GetMovieDetailsLocalized(c.Request.Context(), id, locale)
+	if err != nil {
+		RespondTMDBError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, movie)
+}
+
+// GetTVShow godoc
+// @Summary      TMDB-Seriendetails abrufen
+// @Description  Lädt die Details einer Serie bei TMDB einschließlich Staffeln, Genres und Credits. Fehlt eine übersetzte Beschreibung, wird die englische geliefert.
+// @Tags         tmdb
+// @Produce      json
+// @Param        id        path      int     true   "TMDB-ID der Serie"
+// @Param        language  query     string  false  "Sprache der Metadaten, z. B. de-DE"
+// @Success      200  {object}  tmdb.TVShow
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      429  {object}  models.ErrorResponse
+// @Failure      502  {object}  models.ErrorResponse
+// @Failure      503  {object}  models.ErrorResponse
+// @Failure      504  {object}  models.ErrorResponse
+// @Router       /tmdb/tv/{id} [get]
+func (h *TMDBHandler) GetTVShow(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid TV show ID"})
+		return
+	}
+
+	locale, ok := h.locale(c)
+	if !ok {
+		return
+	}
+
+	show, err := h.client.GetTVDetailsLocalized(c.Request.Context(), id, locale)
+	if err != nil {
+		RespondTMDBError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, show)
+}
+
+// GetSeason godoc
+// @Summary      TMDB-Staffel abrufen
+// @Description  Lädt eine Staffel einer Serie bei TMDB mit ihren Episoden; Staffel 0 enthält die Specials
+// @Tags         tmdb
+// @Produce      json
+// @Param        id        path      int     true   "TMDB-ID der Serie"
+// @Param        season    path      int     true   "Staffelnummer"
+// @Param        language  query     string  false  "Sprache der Metadaten, z. B. de-DE"
+// @Success      200  {object}  tmdb.Season
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      429  {object}  models.ErrorResponse
+// @Failure      502  {object}  models.ErrorResponse
+// @Failure      503  {object}  models.ErrorResponse
+// @Failure      504  {object}  models.ErrorResponse
+// @Router       /tmdb/tv/{id}/season/{season} [get]
+func (h *TMDBHandler) GetSeason(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid TV show ID"})
+		return
+	}
+	season, err := strconv.Atoi(c.Param("season"))
+	if err != nil || season < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid season number"})
+		return
+	}
+
+	locale, ok := h.locale(c)
+	if !ok {
+		return
+	}
+
+	details, err := h.client.GetSeasonDetailsLocalized(c.Request.Context(), id, season, locale)
+	if err != nil {
+		RespondTMDBError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, details)
+}
+
+// locale liest language und region aus der Anfrage; fehlende Angaben übernimmt sie vom Client
+// Bei ungültigen Werten antwortet sie mit 400 und liefert false.
+func (h *TMDBHandler) locale(c *gin.Context) (tmdb.Locale, bool) {
+	locale := h.client.Locale
+	if language := c.Query("language"); language != "" {
+		if !tmdb.ValidLanguage(language) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "language must look like de or de-DE"})
+			return tmdb.Locale{}, false
+		}
+		locale.Language = language
+	}
+	if region := c.Query("region"); region != "" {
+		if !tmdb.ValidRegion(region) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "region must be an ISO 3166-1 code like DE"})
+			return tmdb.Locale{}, false
+		}
+		locale.Region = region
+	}
+	return locale, true
+}
+
+// TestConnection godoc
+// @Summary      TMDB-Verbindung prüfen
+// @Description  Prüft Erreichbarkeit und API-Schlüssel von TMDB (nur Administratoren)
+// @Tags         tmdb
+// @Produce      json
+// @Success      200  {object}  map[string]string
+// @Failure      502  {object}  models.ErrorResponse
+// @Failure      503  {object}  models.ErrorResponse
+// @Router       /tmdb/test [get]
+func (h *TMDBHandler) TestConnection(c *gin.Context) {
+	if err := h.client.TestConnectionContext(c.Request.Context()); err != nil {
+		RespondTMDBError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// GetStats godoc
+// @Summary      TMDB-Anfragestatistik
+// @Description  Zähler der TMDB-Aufrufe seit dem Start: zusammengelegte Aufrufe, gesendete Anfragen, Wiederholungen, gedrosselte Anfragen, 429-Antworten und Treffer im Antwort-Cache (nur Administratoren)
+// @Tags         tmdb
+// @Produce      json
+// @Success      200  {object}  tmdb.Stats
+// @Router       /tmdb/stats [get]
+func (h *TMDBHandler) GetStats(c *gin.Context) {
+	c.JSON(http.StatusOK, h.client.Stats())
+}
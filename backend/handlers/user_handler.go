@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/services"
+	"github.com/gin-gonic/gin"
+)
+
+type UserHandler struct {
+	service *services.AuthService
+}
+
+func NewUserHandler(service *services.AuthService) *UserHandler {
+	return &UserHandler{service: service}
+}
+
+// ListUsers godoc
+// @Summary      Benutzer auflisten
+// @Description  Gibt alle Benutzerkonten mit ihren Rollen zurück (nur für Administratoren)
+// @Tags         users
+// @Produce      json
+// @Success      200  {array}   models.User
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /users [get]
+func (h *UserHandler) ListUsers(c *gin.Context) {
+	users, err := h.service.ListUsers()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, users)
+}
+
+// UpdateUserRole godoc
+// @Summary      Rolle eines Benutzers ändern
+// @Description  Setzt die Rolle (viewer, editor, admin) eines Benutzers (nur für Administratoren)
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                true  "User ID"
+// @Param        role  body      models.RoleUpdate  true  "Neue Rolle"
+// @Success      200  {object}  models.User
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /users/{id}/role [put]
+func (h *UserHandler) UpdateUserRole(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var update models.RoleUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.service.UpdateRole(uint(id), update.Role)
+	if err != nil {
+		if errors.Is(err, services.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
@@ -5,9 +5,9 @@ import (
 	"log"
 	"net/http"
 	"os"
-	"strconv"
 	"time"
 
+	"github.com/MichaelKlank/movie-collector/backend/auth"
 	"github.com/MichaelKlank/movie-collector/backend/cache"
 	"github.com/MichaelKlank/movie-collector/backend/db"
 	"github.com/MichaelKlank/movie-collector/backend/handlers"
@@ -64,17 +64,102 @@ func main() {
 	}
 
 	// Migrate the schema
-	if err := db.GetDB().AutoMigrate(&models.Movie{}); err != nil {
+	if err := db.GetDB().AutoMigrate(&models.Movie{}, &models.User{}, &models.APIToken{}, &models.RevocationEntry{}, &models.Copy{}, &models.Location{}, &models.Loan{}, &models.Genre{}, &models.Person{}, &models.Credit{}); err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
 
+	// Artikel, die bei der alphabetischen Einordnung entfallen
+	if err := models.SetSortTitleLanguages(models.SortTitleLanguages()); err != nil {
+		log.Printf("%v, verwende %s", err, models.DefaultSortTitleLanguages)
+	}
+
+	// Volltextsuche (nur PostgreSQL); ohne Index bleibt die LIKE-Suche aktiv
+	searchConfig := db.SearchConfig()
+	fullTextSearch := true
+	if err := db.EnsureFullTextSearch(db.GetDB(), searchConfig); err != nil {
+		log.Printf("Volltextsuche nicht verfügbar, verwende LIKE-Suche: %v", err)
+		fullTextSearch = false
+	}
+
+	// Unscharfe Suche per pg_trgm (nur PostgreSQL); ohne Erweiterung wird die Editierdistanz im Prozess berechnet
+	trigramSearch := true
+	if err := db.EnsureTrigramSearch(db.GetDB()); err != nil {
+		log.Printf("pg_trgm nicht verfügbar, verwende Editierdistanz für die unscharfe Suche: %v", err)
+		trigramSearch = false
+	}
+
+	// Sprache und Region der TMDB-Metadaten
+	locale, err := tmdb.LocaleFromEnv()
+	if err != nil {
+		log.Print(err)
+	}
+	tmdb.Shared().Locale = locale
+
 	// Initialisiere den Redis-Cache
 	cache.InitRedisCache()
+	if cache.RedisEnabled() {
+		// TMDB-Antworten zusätzlich in Redis ablegen, damit Neustarts und weitere Instanzen sie nutzen
+		tmdb.Shared().UseStore(cache.RedisStore)
+	}
+
+	// JWT-Konfiguration laden
+	jwtConfig, err := auth.NewJWTConfig()
+	if err != nil {
+		log.Fatal("Fehler beim Laden der JWT-Konfiguration:", err)
+	}
+
+	// Widerrufsstatus der Refresh-Tokens liegt in Redis, ohne Redis in der Datenbank
+	var revocations services.RevocationStore
+	if cache.RedisEnabled() {
+		revocations = cache.NewRevocationStore()
+	} else {
+		revocationRepo := repositories.NewRevocationRepository(db.GetDB())
+		if err := revocationRepo.DeleteExpired(); err != nil {
+			log.Printf("Fehler beim Löschen abgelaufener Widerrufseinträge: %v", err)
+		}
+		revocations = revocationRepo
+	}
 
 	// Initialize dependencies
+	userRepo := repositories.NewUserRepository(db.GetDB())
 	movieRepo := repositories.NewMovieRepository(db.GetDB())
-	movieService := services.NewMovieService(movieRepo)
+	if fullTextSearch {
+		movieRepo.EnableFullTextSearch(searchConfig)
+	}
+	if trigramSearch {
+		movieRepo.EnableTrigramSearch()
+	}
+	if err := movieRepo.BackfillSearchTitles(); err != nil {
+		log.Printf("Fehler beim Setzen der Suchtitel: %v", err)
+	}
+	if err := movieRepo.BackfillSortTitles(); err != nil {
+		log.Printf("Fehler beim Setzen der Sortiertitel: %v", err)
+	}
+	authService := services.NewAuthService(userRepo, jwtConfig, revocations)
+	authHandler := handlers.NewAuthHandler(authService, jwtConfig)
+	userHandler := handlers.NewUserHandler(authService)
+	apiTokenRepo := repositories.NewAPITokenRepository(db.GetDB())
+	apiTokenService := services.NewAPITokenService(apiTokenRepo, userRepo)
+	apiTokenHandler := handlers.NewAPITokenHandler(apiTokenService)
+	locationRepo := repositories.NewLocationRepository(db.GetDB())
+	movieService := services.NewMovieService(movieRepo, locationRepo)
 	movieHandler := handlers.NewMovieHandler(movieService)
+	copyRepo := repositories.NewCopyRepository(db.GetDB())
+	copyService := services.NewCopyService(copyRepo, movieRepo, locationRepo)
+	copyHandler := handlers.NewCopyHandler(copyService)
+	locationService := services.NewLocationService(locationRepo, movieRepo)
+	locationHandler := handlers.NewLocationHandler(locationService)
+	loanRepo := repositories.NewLoanRepository(db.GetDB())
+	loanService := services.NewLoanService(loanRepo, movieRepo)
+	loanHandler := handlers.NewLoanHandler(loanService)
+	metadataRepo := repositories.NewMetadataRepository(db.GetDB())
+	metadataService := services.NewMetadataService(tmdb.Shared(), movieRepo, metadataRepo)
+	metadataHandler := handlers.NewMetadataHandler(metadataService)
+	// Mit TMDB-Schlüssel erhalten neu verknüpfte Filme ihre Genres, Laufzeit und Credits sofort
+	if os.Getenv("TMDB_API_KEY") != "" {
+		movieService.UseMetadata(metadataService)
+	}
+	tmdbHandler := handlers.NewTMDBHandler(tmdb.Shared())
 	imageHandler := handlers.NewImageHandler(movieService)
 	versionHandler := handlers.NewVersionHandler()
 
@@ -118,24 +203,47 @@ func main() {
 	// Version route
 	r.GET("/version", versionHandler.GetVersion)
 
-	// Movie routes mit Cache für GET-Anfragen
-	r.GET("/movies", gincache.CachePage(cache.RedisStore, 2*time.Minute, movieHandler.GetMovies))
-	r.GET("/movies/search", gincache.CachePage(cache.RedisStore, 1*time.Minute, movieHandler.SearchMovies))
-	r.GET("/movies/:id", gincache.CachePage(cache.RedisStore, 5*time.Minute, movieHandler.GetMovie))
-	r.POST("/movies", func(c *gin.Context) {
+	// Auth routes
+	r.POST("/auth/register", authHandler.Register)
+	r.POST("/auth/login", authHandler.Login)
+	r.POST("/auth/refresh", authHandler.Refresh)
+	r.POST("/auth/logout", authHandler.Logout)
+
+	// Abmelden auf allen Geräten erfordert eine Anmeldung
+	r.POST("/auth/logout-all", auth.AuthMiddleware(string(jwtConfig.Secret), false, apiTokenService), auth.DenyAPITokens(), authHandler.LogoutAll)
+
+	// Persönliche API-Tokens lassen sich nur mit einer Anmeldung verwalten, nicht mit einem API-Token
+	tokens := r.Group("/auth/tokens", auth.AuthMiddleware(string(jwtConfig.Secret), false, apiTokenService), auth.DenyAPITokens())
+	tokens.GET("", apiTokenHandler.ListTokens)
+	tokens.POST("", apiTokenHandler.CreateToken)
+	tokens.DELETE("/:id", apiTokenHandler.RevokeToken)
+
+	// Authentifizierung für Film- und Bildrouten; anonyme GET-Anfragen sind konfigurierbar
+	authMiddleware := auth.AuthMiddleware(string(jwtConfig.Secret), os.Getenv("AUTH_ANONYMOUS_READ") != "false", apiTokenService)
+	movies := r.Group("/movies", authMiddleware)
+	// Editoren dürfen Filme anlegen und bearbeiten, nur Administratoren dürfen löschen
+	movieEditors := movies.Group("", auth.RequireRole(auth.RoleEditor))
+	movieAdmins := movies.Group("", auth.RequireRole(auth.RoleAdmin))
+
+	// Movie routes mit Cache für GET-Anfragen (getrennt pro Benutzer)
+	movies.GET("", cache.CachePageByUser(cache.RedisStore, 2*time.Minute, movieHandler.GetMovies))
+	movies.GET("/search", cache.CachePageByUser(cache.RedisStore, 1*time.Minute, movieHandler.SearchMovies))
+	movies.GET("/index", cache.CachePageByUser(cache.RedisStore, 2*time.Minute, movieHandler.GetMovieIndex))
+	movies.GET("/:id", cache.CachePageByUser(cache.RedisStore, 5*time.Minute, movieHandler.GetMovie))
+	movieEditors.POST("", func(c *gin.Context) {
 		movieHandler.CreateMovie(c)
 		// Cache nach Erstellung eines Films invalidieren
 		log.Printf("Cache wird nach Film-Erstellung invalidiert")
 		cache.ClearAllCaches()
 	})
-	r.PUT("/movies/:id", func(c *gin.Context) {
+	movieEditors.PUT("/:id", func(c *gin.Context) {
 		movieHandler.UpdateMovie(c)
 		// Cache nach Update eines Films invalidieren
 		id := c.Param("id")
 		log.Printf("Cache wird nach Film-Update invalidiert: id=%s", id)
 		cache.ClearAllCaches()
 	})
-	r.DELETE("/movies/:id", func(c *gin.Context) {
+	movieAdmins.DELETE("/:id", func(c *gin.Context) {
 		movieHandler.DeleteMovie(c)
 		// Cache nach Löschen eines Films invalidieren
 		id := c.Param("id")
@@ -143,65 +251,104 @@ func main() {
 		cache.ClearAllCaches()
 	})
 
-	// Image routes
-	r.POST("/movies/:id/image", imageHandler.UploadImage)
-	r.GET("/movies/:id/image", imageHandler.GetImage)
-	r.DELETE("/movies/:id/image", imageHandler.DeleteImage)
+	// Film von der Wunschliste in den Bestand übernehmen
+	movieEditors.POST("/:id/acquire", func(c *gin.Context) {
+		movieHandler.AcquireMovie(c)
+		cache.ClearAllCaches()
+	})
 
-	// TMDB routes
-	r.GET("/tmdb/test", func(c *gin.Context) {
-		client := tmdb.NewClient()
-		if err := client.TestConnection(); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	// Genres, Laufzeit und Credits aus TMDB übernehmen
+	movieEditors.POST("/:id/metadata", func(c *gin.Context) {
+		metadataHandler.SyncMovieMetadata(c)
+		cache.ClearAllCaches()
 	})
 
-	// Cache für TMDB-Suche (kürzere Ablaufzeit von 1 Minute)
-	r.GET("/tmdb/search", gincache.CachePage(cache.RedisStore, time.Minute, func(c *gin.Context) {
-		query := c.Query("query")
-		if query == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "query parameter is required"})
-			return
-		}
+	// Exemplar-Routen; Änderungen invalidieren den Cache, da GET /movies/:id die Exemplare enthält
+	movies.GET("/:id/copies", copyHandler.ListCopies)
+	movies.GET("/:id/copies/:copyId", copyHandler.GetCopy)
+	movieEditors.POST("/:id/copies", func(c *gin.Context) {
+		copyHandler.CreateCopy(c)
+		cache.ClearAllCaches()
+	})
+	movieEditors.PUT("/:id/copies/:copyId", func(c *gin.Context) {
+		copyHandler.UpdateCopy(c)
+		cache.ClearAllCaches()
+	})
+	movieAdmins.DELETE("/:id/copies/:copyId", func(c *gin.Context) {
+		copyHandler.DeleteCopy(c)
+		cache.ClearAllCaches()
+	})
 
-		client := tmdb.NewClient()
-		movies, err := client.SearchMovies(query)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
-		}
+	// Image routes
+	movieEditors.POST("/:id/image", imageHandler.UploadImage)
+	movies.GET("/:id/image", imageHandler.GetImage)
+	movieAdmins.DELETE("/:id/image", imageHandler.DeleteImage)
+
+	// Lagerorte verwenden dieselben Rollen wie die Filmrouten
+	locations := r.Group("/locations", authMiddleware)
+	locationEditors := locations.Group("", auth.RequireRole(auth.RoleEditor))
+	locationAdmins := locations.Group("", auth.RequireRole(auth.RoleAdmin))
+	locations.GET("", locationHandler.ListLocations)
+	locations.GET("/:id", locationHandler.GetLocation)
+	locations.GET("/:id/movies", locationHandler.GetLocationMovies)
+	locationEditors.POST("", locationHandler.CreateLocation)
+	locationEditors.PUT("/:id", func(c *gin.Context) {
+		locationHandler.UpdateLocation(c)
+		cache.ClearAllCaches()
+	})
+	locationAdmins.DELETE("/:id", func(c *gin.Context) {
+		locationHandler.DeleteLocation(c)
+		cache.ClearAllCaches()
+	})
 
-		c.JSON(http.StatusOK, movies)
-	}))
+	// Ausleihen; Verleihen und Rückgabe invalidieren den Cache, da GET /movies/:id die laufende Ausleihe enthält.
+	// Der Verlauf nennt die Entleiher und erfordert deshalb eine Anmeldung.
+	movies.GET("/:id/loans", auth.RequireRole(auth.RoleViewer), loanHandler.GetMovieLoans)
+	movieEditors.POST("/:id/loans", func(c *gin.Context) {
+		loanHandler.LendMovie(c)
+		cache.ClearAllCaches()
+	})
+	movieEditors.POST("/:id/return", func(c *gin.Context) {
+		loanHandler.ReturnMovie(c)
+		cache.ClearAllCaches()
+	})
+	// Ausleihen enthalten Namen und Kontaktdaten der Entleiher und sind nie anonym lesbar
+	loans := r.Group("/loans", auth.AuthMiddleware(string(jwtConfig.Secret), false, apiTokenService))
+	loans.GET("", loanHandler.ListActiveLoans)
+	loans.GET("/overdue", loanHandler.ListOverdueLoans)
+
+	// Übersichten nach Person und Genre
+	people := r.Group("/people", authMiddleware)
+	people.GET("/:id/movies", metadataHandler.GetPersonMovies)
+	genres := r.Group("/genres", authMiddleware)
+	genres.GET("", metadataHandler.ListGenres)
+	genres.GET("/:id/movies", metadataHandler.GetGenreMovies)
+
+	// Administrative Routen erfordern immer eine Anmeldung mit Administratorrolle
+	admin := r.Group("", auth.AuthMiddleware(string(jwtConfig.Secret), false, apiTokenService), auth.RequireRole(auth.RoleAdmin))
+	admin.GET("/users", userHandler.ListUsers)
+	admin.PUT("/users/:id/role", userHandler.UpdateUserRole)
+	admin.POST("/cache/flush", func(c *gin.Context) {
+		cache.ClearAllCaches()
+		c.JSON(http.StatusOK, gin.H{"message": "Cache flushed successfully"})
+	})
 
-	// Cache für TMDB-Filmdetails (längere Ablaufzeit von 1 Stunde)
-	r.GET("/tmdb/movie/:id", gincache.CachePage(cache.RedisStore, time.Hour, func(c *gin.Context) {
-		idStr := c.Param("id")
-		if idStr == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "movie ID is required"})
-			return
-		}
+	// TMDB routes
+	admin.GET("/tmdb/test", tmdbHandler.TestConnection)
+	admin.GET("/tmdb/stats", tmdbHandler.GetStats)
 
-		id, err := strconv.Atoi(idStr)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid movie ID"})
-			return
-		}
+	// Cache für TMDB-Suche (kürzere Ablaufzeit von 1 Minute)
+	r.GET("/tmdb/search", gincache.CachePage(cache.RedisStore, time.Minute, tmdbHandler.SearchMovies))
 
-		client := tmdb.NewClient()
-		movie, err := client.GetMovieDetails(id)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
-		}
+	// Cache für TMDB-Filmdetails (längere Ablaufzeit von 1 Stunde)
+	r.GET("/tmdb/movie/:id", gincache.CachePage(cache.RedisStore, time.Hour, tmdbHandler.GetMovie))
 
-		c.JSON(http.StatusOK, movie)
-	}))
+	// Serien und Staffeln ändern sich ebenso selten wie Filmdetails
+	r.GET("/tmdb/tv/:id", gincache.CachePage(cache.RedisStore, time.Hour, tmdbHandler.GetTVShow))
+	r.GET("/tmdb/tv/:id/season/:season", gincache.CachePage(cache.RedisStore, time.Hour, tmdbHandler.GetSeason))
 
 	// SBOM route
-	r.GET("/sbom", func(c *gin.Context) {
+	admin.GET("/sbom", func(c *gin.Context) {
 		sbomData, err := os.ReadFile("sbom.json")
 		if err != nil {
 			log.Printf("Error reading SBOM file: %v", err)
@@ -0,0 +1,41 @@
+package models
+
+import "strings"
+
+// IndexOther ist der Eintrag des Alphabet-Index für Titel, die mit einer Ziffer oder einem Zeichen beginnen
+const IndexOther = "#"
+
+// IndexLetters sind die Buchstaben des Alphabet-Index in gefalteter Form
+var IndexLetters = strings.Split("abcdefghijklmnopqrstuvwxyz", "")
+
+// IndexLetter liefert den Eintrag des Alphabet-Index für einen Sortiertitel: den Anfangsbuchstaben
+// ohne Akzente in Großbuchstaben oder IndexOther
+func IndexLetter(sortTitle string) string {
+	folded := FoldText(sortTitle)
+	if folded != "" && folded[0] >= 'a' && folded[0] <= 'z' {
+		return strings.ToUpper(folded[:1])
+	}
+	return IndexOther
+}
+
+// AlphabetEntry ist ein Eintrag des Alphabet-Index mit der Anzahl der Filme und der Stelle,
+// an der der erste davon in der nach Sortiertitel geordneten Liste steht (page bzw. cursor)
+type AlphabetEntry struct {
+	Letter string `json:"letter" example:"M"`
+	Count  int64  `json:"count" example:"12"`
+	Page   int    `json:"page" example:"3"`
+	// Cursor für GET /movies?cursor=...; leer für den Anfang der Liste
+	Cursor string `json:"cursor" example:"eyJzIjoidGl0bGUiLCJvIjoiYXNjIiwidiI6Ikx1Y3kiLCJpZCI6N30"`
+}
+
+// AlphabetIndexMeta enthält die Seitengröße, auf die sich page bezieht, und die Anzahl aller Filme
+type AlphabetIndexMeta struct {
+	Limit int   `json:"limit" example:"20"`
+	Total int64 `json:"total" example:"230"`
+}
+
+// AlphabetIndexResponse ist die Antwort von GET /movies/index
+type AlphabetIndexResponse struct {
+	Data []AlphabetEntry   `json:"data"`
+	Meta AlphabetIndexMeta `json:"meta"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// APIToken ist ein langlebiger persönlicher Zugangstoken für Skripte und Integrationen
+// Gespeichert wird nur der SHA-256-Hash des Tokens
+type APIToken struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	UserID     uint       `json:"-" gorm:"index;not null"`
+	User       *User      `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Name       string     `json:"name" gorm:"not null"`
+	Prefix     string     `json:"prefix"`
+	TokenHash  string     `json:"-" gorm:"uniqueIndex;not null"`
+	Scope      string     `json:"scope" gorm:"not null"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// APITokenRequest ist der Request-Body zum Anlegen eines API-Tokens
+type APITokenRequest struct {
+	Name  string `json:"name" binding:"required,max=100"`
+	Scope string `json:"scope" binding:"required,oneof=read write"`
+}
+
+// CreatedAPIToken enthält den Klartext-Token, der nur beim Anlegen einmalig zurückgegeben wird
+type CreatedAPIToken struct {
+	APIToken
+	Token string `json:"token"`
+}
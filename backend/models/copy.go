@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Zustand eines Exemplars
+const (
+	ConditionNew     = "new"
+	ConditionLikeNew = "like_new"
+	ConditionGood    = "good"
+	ConditionFair    = "fair"
+	ConditionPoor    = "poor"
+)
+
+// Copy ist ein einzelnes Exemplar eines Films, z. B. die DVD und die spätere Blu-ray desselben Films
+// Die Filmdaten selbst bleiben pro TMDB-ID nur einmal in Movie gespeichert.
+type Copy struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	MovieID      uint      `json:"movie_id" gorm:"index;not null"`
+	Movie        *Movie    `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Format       string    `json:"format" binding:"required,oneof=dvd bluray uhd digital" enums:"dvd,bluray,uhd,digital" example:"bluray"`
+	Barcode      string    `json:"barcode,omitempty" gorm:"index" binding:"omitempty,max=32" example:"5051890012345"`
+	PurchaseDate string    `json:"purchase_date,omitempty" binding:"omitempty,datetime=2006-01-02" example:"2024-03-15"`
+	Price        float64   `json:"price,omitempty" binding:"omitempty,min=0" example:"19.99"`
+	Condition    string    `json:"condition,omitempty" binding:"omitempty,oneof=new like_new good fair poor" enums:"new,like_new,good,fair,poor"`
+	LocationID   *uint     `json:"location_id,omitempty" gorm:"index"`
+	Location     *Location `json:"location,omitempty" gorm:"constraint:OnDelete:SET NULL" binding:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
@@ -0,0 +1,51 @@
+package models
+
+// Art einer Mitwirkung
+const (
+	CreditCast = "cast"
+	CreditCrew = "crew"
+)
+
+// Genre ist ein TMDB-Genre; die Namen werden beim Abgleich mit TMDB übernommen
+type Genre struct {
+	ID     uint   `json:"id" gorm:"primaryKey"`
+	TMDBId int    `json:"tmdb_id" gorm:"uniqueIndex"`
+	Name   string `json:"name" example:"Science Fiction"`
+}
+
+// Person ist ein Darsteller oder Mitglied des Stabs, eindeutig über die TMDB-ID
+type Person struct {
+	ID          uint   `json:"id" gorm:"primaryKey"`
+	TMDBId      int    `json:"tmdb_id" gorm:"uniqueIndex"`
+	Name        string `json:"name" gorm:"index" example:"Keanu Reeves"`
+	ProfilePath string `json:"profile_path,omitempty"`
+}
+
+// Credit verknüpft eine Person mit einem Film, als Rolle (cast) oder Aufgabe im Stab (crew)
+type Credit struct {
+	ID         uint    `json:"id" gorm:"primaryKey"`
+	MovieID    uint    `json:"movie_id" gorm:"index;not null"`
+	PersonID   uint    `json:"person_id" gorm:"index;not null"`
+	Person     *Person `json:"person,omitempty"`
+	Kind       string  `json:"kind" gorm:"not null" enums:"cast,crew"`
+	Character  string  `json:"character,omitempty" example:"Neo"`
+	Job        string  `json:"job,omitempty" gorm:"index" example:"Director"`
+	Department string  `json:"department,omitempty"`
+	Order      int     `json:"order" gorm:"column:credit_order"`
+}
+
+// GenreCount ist ein Genre mit der Anzahl der Filme in der Sammlung
+type GenreCount struct {
+	ID         uint   `json:"id"`
+	TMDBId     int    `json:"tmdb_id"`
+	Name       string `json:"name" example:"Science Fiction"`
+	MovieCount int64  `json:"movie_count" example:"12"`
+}
+
+// GenreListResponse ist die Antwort von GET /genres
+type GenreListResponse struct {
+	Data []GenreCount `json:"data"`
+	Meta struct {
+		Total int `json:"total"`
+	} `json:"meta"`
+}
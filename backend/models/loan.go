@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// LoanDateLayout ist das Datumsformat für Ausleihen
+const LoanDateLayout = "2006-01-02"
+
+// Loan ist eine Ausleihe eines Films an eine andere Person
+// Eine Ausleihe ist aktiv, solange ReturnedOn leer ist; zurückgegebene Ausleihen bleiben als Historie erhalten.
+// Ein partieller eindeutiger Index lässt je Film nur eine aktive Ausleihe zu.
+type Loan struct {
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	MovieID         uint      `json:"movie_id" gorm:"index;not null;uniqueIndex:idx_loans_active_movie,where:returned_on = ''"`
+	Movie           *Movie    `json:"movie,omitempty" gorm:"constraint:OnDelete:CASCADE"`
+	OwnerID         *uint     `json:"-" gorm:"index"`
+	BorrowerName    string    `json:"borrower_name" gorm:"not null" example:"Max Mustermann"`
+	BorrowerContact string    `json:"borrower_contact,omitempty" example:"max@example.com"`
+	LentOn          string    `json:"lent_on" gorm:"not null" example:"2024-03-01"`
+	DueOn           string    `json:"due_on,omitempty" gorm:"index" example:"2024-03-15"`
+	ReturnedOn      string    `json:"returned_on,omitempty" gorm:"index" example:"2024-03-12"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// LoanRequest ist der Request-Body zum Verleihen eines Films
+// Ohne lent_on gilt das heutige Datum.
+type LoanRequest struct {
+	BorrowerName    string `json:"borrower_name" binding:"required,max=100"`
+	BorrowerContact string `json:"borrower_contact" binding:"omitempty,max=200"`
+	LentOn          string `json:"lent_on" binding:"omitempty,datetime=2006-01-02"`
+	DueOn           string `json:"due_on" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// ReturnRequest ist der optionale Request-Body zur Rückgabe; ohne returned_on gilt das heutige Datum
+type ReturnRequest struct {
+	ReturnedOn string `json:"returned_on" binding:"omitempty,datetime=2006-01-02"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// Location ist ein Lagerort der Sammlung, z. B. Wohnzimmer / Regal 2 / Fach 3
+type Location struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	OwnerID   *uint     `json:"owner_id,omitempty" gorm:"index"`
+	Owner     *User     `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Room      string    `json:"room" gorm:"not null" binding:"required,max=100" example:"Wohnzimmer"`
+	Shelf     string    `json:"shelf,omitempty" binding:"omitempty,max=100" example:"Regal 2"`
+	Slot      string    `json:"slot,omitempty" binding:"omitempty,max=100" example:"Fach 3"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
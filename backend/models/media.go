@@ -0,0 +1,71 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Medienformate eines Films
+const (
+	FormatDVD     = "dvd"
+	FormatBluray  = "bluray"
+	FormatUHD     = "uhd"
+	FormatDigital = "digital"
+)
+
+// RegionFree kennzeichnet Discs ohne Regionalcode
+const RegionFree = "free"
+
+// Medientypen der Sammlung; Serien werden meist als Staffel-Boxen erfasst
+const (
+	MediaTypeMovie = "movie"
+	MediaTypeTV    = "tv"
+)
+
+// ErrInvalidMedia wird von ValidateMedia für widersprüchliche Medienangaben zurückgegeben
+var ErrInvalidMedia = errors.New("Ungültige Medienangaben")
+
+// ValidateMedia prüft, ob Format, Regionalcode und Anzahl der Discs sowie Medientyp und Staffeln zueinander passen
+// Die Wertebereiche der einzelnen Felder werden bereits beim Binding geprüft.
+func (m *Movie) ValidateMedia() error {
+	if err := m.validateSeasons(); err != nil {
+		return err
+	}
+	switch m.Format {
+	case "":
+		if m.Region != "" {
+			return fmt.Errorf("%w: Ein Regionalcode erfordert ein physisches Medienformat", ErrInvalidMedia)
+		}
+	case FormatDigital:
+		if m.Region != "" || m.DiscCount > 0 {
+			return fmt.Errorf("%w: Digitale Filme haben weder Regionalcode noch Discs", ErrInvalidMedia)
+		}
+	case FormatDVD:
+		if m.Region != "" && m.Region != RegionFree && !strings.ContainsAny(m.Region, "012345678") {
+			return fmt.Errorf("%w: DVDs verwenden die Regionalcodes 0 bis 8", ErrInvalidMedia)
+		}
+	case FormatBluray, FormatUHD:
+		if m.Region != "" && m.Region != RegionFree && !strings.ContainsAny(m.Region, "ABC") {
+			return fmt.Errorf("%w: Blu-rays verwenden die Regionalcodes A, B oder C", ErrInvalidMedia)
+		}
+	}
+	return nil
+}
+
+// validateSeasons prüft die Staffelangaben: nur bei Serien und nur als vollständiger Bereich
+func (m *Movie) validateSeasons() error {
+	if m.MediaType != MediaTypeTV {
+		if m.SeasonFrom > 0 || m.SeasonTo > 0 || m.EpisodeCount > 0 {
+			return fmt.Errorf("%w: Staffeln und Episoden gibt es nur bei Serien", ErrInvalidMedia)
+		}
+		return nil
+	}
+	if (m.SeasonFrom == 0) != (m.SeasonTo == 0) {
+		return fmt.Errorf("%w: Eine Staffel-Box benötigt erste und letzte Staffel", ErrInvalidMedia)
+	}
+	if m.SeasonFrom > m.SeasonTo {
+		return fmt.Errorf("%w: Die erste Staffel liegt nach der letzten", ErrInvalidMedia)
+	}
+	return nil
+}
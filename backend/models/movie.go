@@ -14,18 +14,48 @@ import (
 )
 
 type Movie struct {
-	ID          uint      `json:"id" gorm:"primaryKey"`
-	Title       string    `json:"title" binding:"required"`
-	Description string    `json:"description"`
-	Year        int       `json:"year" binding:"required"`
-	ImagePath   string    `json:"image_path"`
-	PosterPath  string    `json:"poster_path"`
-	TMDBId      string    `json:"tmdb_id"`
-	Overview    string    `json:"overview"`
-	ReleaseDate string    `json:"release_date"`
-	Rating      float32   `json:"rating"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID    uint   `json:"id" gorm:"primaryKey"`
+	Title string `json:"title" binding:"required"`
+	// OriginalTitle ist der Titel in der Originalsprache, Title der lokalisierte
+	OriginalTitle string `json:"original_title,omitempty" binding:"omitempty,max=255" example:"The Matrix"`
+	SearchTitle   string `json:"-" gorm:"index"`
+	// MediaType unterscheidet Filme und Serien; eine Staffel-Box umfasst die Staffeln SeasonFrom bis SeasonTo
+	MediaType    string `json:"media_type" gorm:"not null;default:movie;index" binding:"omitempty,oneof=movie tv" enums:"movie,tv" example:"tv"`
+	SeasonFrom   int    `json:"season_from,omitempty" gorm:"not null;default:0" binding:"omitempty,min=1,max=100" example:"1"`
+	SeasonTo     int    `json:"season_to,omitempty" gorm:"not null;default:0" binding:"omitempty,min=1,max=100" example:"5"`
+	EpisodeCount int    `json:"episode_count,omitempty" gorm:"not null;default:0" binding:"omitempty,min=1,max=10000" example:"62"`
+	// SortTitle bestimmt die alphabetische Einordnung; leer wird er aus Title ohne führenden Artikel abgeleitet
+	SortTitle       string `json:"sort_title" binding:"omitempty,max=255" example:"Matrix"`
+	SortTitleCustom bool   `json:"sort_title_custom" binding:"-"`
+	// SortKey ist der gefaltete Sortiertitel, nach dem Listen geordnet werden
+	SortKey         string    `json:"-" gorm:"index"`
+	Description     string    `json:"description"`
+	Year            int       `json:"year" binding:"required"`
+	ImagePath       string    `json:"image_path"`
+	PosterPath      string    `json:"poster_path"`
+	TMDBId          string    `json:"tmdb_id"`
+	Overview        string    `json:"overview"`
+	ReleaseDate     string    `json:"release_date"`
+	Rating          float32   `json:"rating"`
+	Runtime         int       `json:"runtime,omitempty" binding:"omitempty,min=0" example:"136"`
+	Format          string    `json:"format,omitempty" binding:"omitempty,oneof=dvd bluray uhd digital" enums:"dvd,bluray,uhd,digital" example:"bluray"`
+	Region          string    `json:"region,omitempty" binding:"omitempty,oneof=0 1 2 3 4 5 6 7 8 A B C free" example:"B"`
+	DiscCount       int       `json:"disc_count,omitempty" binding:"omitempty,min=1,max=100" example:"2"`
+	Edition         string    `json:"edition,omitempty" binding:"omitempty,max=100" example:"Director's Cut"`
+	Status          string    `json:"status" gorm:"not null;default:owned;index" binding:"omitempty,oneof=owned wishlist preordered" enums:"owned,wishlist,preordered" example:"owned"`
+	TargetPrice     float64   `json:"target_price,omitempty" binding:"omitempty,min=0" example:"14.99"`
+	PreferredFormat string    `json:"preferred_format,omitempty" binding:"omitempty,oneof=dvd bluray uhd digital" enums:"dvd,bluray,uhd,digital"`
+	Priority        int       `json:"priority,omitempty" binding:"omitempty,min=1,max=5" example:"1"`
+	LocationID      *uint     `json:"location_id,omitempty" gorm:"index"`
+	Location        *Location `json:"location,omitempty" gorm:"constraint:OnDelete:SET NULL" binding:"-"`
+	OwnerID         *uint     `json:"owner_id,omitempty" gorm:"index"`
+	Owner           *User     `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Copies          []Copy    `json:"copies,omitempty" binding:"-"`
+	ActiveLoan      *Loan     `json:"active_loan,omitempty" gorm:"foreignKey:MovieID" binding:"-"`
+	Genres          []Genre   `json:"genres,omitempty" gorm:"many2many:movie_genres" binding:"-"`
+	Credits         []Credit  `json:"credits,omitempty" binding:"-"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
 }
 
 func GetMovies(db *gorm.DB) gin.HandlerFunc {
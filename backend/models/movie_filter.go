@@ -0,0 +1,59 @@
+package models
+
+// Sortierfelder für Filmlisten
+const (
+	SortTitle     = "title"
+	SortYear      = "year"
+	SortRating    = "rating"
+	SortCreatedAt = "created_at"
+	SortUpdatedAt = "updated_at"
+)
+
+// MovieFilter enthält die optionalen Filter für Filmlisten und die Suche
+// Leere Felder schränken die Ergebnisse nicht ein. Sort und Order bestimmen die Reihenfolge (Standard: Titel aufsteigend).
+type MovieFilter struct {
+	Format     string  `form:"format" binding:"omitempty,oneof=dvd bluray uhd digital"`
+	Region     string  `form:"region" binding:"omitempty,oneof=0 1 2 3 4 5 6 7 8 A B C free"`
+	Edition    string  `form:"edition" binding:"omitempty,max=100"`
+	DiscCount  int     `form:"disc_count" binding:"omitempty,min=1,max=100"`
+	Status     string  `form:"status" binding:"omitempty,oneof=owned wishlist preordered all"`
+	YearFrom   int     `form:"year_from" binding:"omitempty,min=1888,max=2100"`
+	YearTo     int     `form:"year_to" binding:"omitempty,min=1888,max=2100"`
+	RatingMin  float32 `form:"rating_min" binding:"omitempty,min=0,max=10"`
+	HasImage   *bool   `form:"has_image"`
+	TMDBLinked *bool   `form:"tmdb_linked"`
+	MediaType  string  `form:"media_type" binding:"omitempty,oneof=movie tv"`
+	// StartsWith ist ein Eintrag des Alphabet-Index: ein Buchstabe A-Z oder "#"
+	StartsWith string `form:"starts_with" binding:"omitempty,len=1"`
+	Sort       string `form:"sort" binding:"omitempty,oneof=title year rating created_at updated_at"`
+	Order      string `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+// DecadeFacet ist die Anzahl der Filme eines Jahrzehnts, z. B. 1990 für 1990-1999
+type DecadeFacet struct {
+	Decade int   `json:"decade" example:"1990"`
+	Count  int64 `json:"count" example:"42"`
+}
+
+// RatingFacet ist die Anzahl der Filme in einem Bewertungsbereich ab Min bis unter Max
+type RatingFacet struct {
+	Band  string  `json:"band" example:"7-8"`
+	Min   float32 `json:"min" example:"7"`
+	Max   float32 `json:"max" example:"8"`
+	Count int64   `json:"count" example:"17"`
+}
+
+// MovieFacets enthält die Trefferzahlen für Filterchips
+type MovieFacets struct {
+	Decades []DecadeFacet `json:"decades"`
+	Ratings []RatingFacet `json:"ratings"`
+}
+
+// RatingBands sind die Bewertungsbereiche der Facetten; der letzte Bereich schließt 10 ein, Filme ohne Bewertung zählen nicht mit
+var RatingBands = []RatingFacet{
+	{Band: "0-5", Min: 0, Max: 5},
+	{Band: "5-6", Min: 5, Max: 6},
+	{Band: "6-7", Min: 6, Max: 7},
+	{Band: "7-8", Min: 7, Max: 8},
+	{Band: "8-10", Min: 8, Max: 10},
+}
@@ -0,0 +1,128 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// ErrInvalidCursor wird für unlesbare oder nicht zur Sortierung passende Cursor zurückgegeben
+var ErrInvalidCursor = errors.New("Ungültiger Cursor")
+
+// PageRequest beschreibt die angeforderte Seite einer Filmliste
+type PageRequest struct {
+	Offset int
+	Limit  int
+	// Cursor aktiviert die Keyset-Paginierung; nil steht für page/limit
+	Cursor *Cursor
+	// SkipTotal spart die Zählabfrage (include_total=false)
+	SkipTotal bool
+}
+
+// PageInfo enthält die Gesamtzahl (-1, wenn nicht gezählt) und im Cursor-Modus die Nachbarseiten
+type PageInfo struct {
+	Total      int64
+	NextCursor string
+	PrevCursor string
+}
+
+// Cursor zeigt auf eine Position in einer sortierten Filmliste: Sortierwert und ID des letzten
+// bzw. ersten Films der vorigen Seite. Ein Cursor ohne ID steht für den Anfang der Liste.
+type Cursor struct {
+	Sort     string `json:"s"`
+	Order    string `json:"o"`
+	Value    string `json:"v,omitempty"`
+	ID       uint   `json:"id,omitempty"`
+	Backward bool   `json:"b,omitempty"`
+}
+
+// StartCursor liefert den Cursor für die erste Seite einer Sortierung
+func StartCursor(filter MovieFilter) *Cursor {
+	return &Cursor{Sort: filter.SortField(), Order: filter.SortOrder()}
+}
+
+// ParseCursor liest einen Cursor aus seiner URL-sicheren Darstellung und prüft, ob er zur Sortierung passt
+// Ein leerer Text liefert den Anfang der Liste.
+func ParseCursor(encoded string, filter MovieFilter) (*Cursor, error) {
+	if encoded == "" {
+		return StartCursor(filter), nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.Sort != filter.SortField() || cursor.Order != filter.SortOrder() {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.ID != 0 {
+		if _, err := cursor.SortValue(); err != nil {
+			return nil, ErrInvalidCursor
+		}
+	}
+	return &cursor, nil
+}
+
+// String liefert die URL-sichere Darstellung des Cursors
+func (c Cursor) String() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// IsStart meldet, ob der Cursor auf den Anfang der Liste zeigt
+func (c Cursor) IsStart() bool {
+	return c.ID == 0
+}
+
+// SortValue wandelt den gespeicherten Sortierwert in den Typ der Sortierspalte um
+func (c Cursor) SortValue() (interface{}, error) {
+	switch c.Sort {
+	case SortYear:
+		return strconv.Atoi(c.Value)
+	case SortRating:
+		return strconv.ParseFloat(c.Value, 64)
+	case SortCreatedAt, SortUpdatedAt:
+		return time.Parse(time.RFC3339Nano, c.Value)
+	default:
+		return c.Value, nil
+	}
+}
+
+// CursorAt erzeugt einen Cursor, der vor (backward) oder hinter einem Film einer sortierten Liste ansetzt
+func CursorAt(filter MovieFilter, movie Movie, backward bool) Cursor {
+	cursor := Cursor{Sort: filter.SortField(), Order: filter.SortOrder(), ID: movie.ID, Backward: backward}
+	switch cursor.Sort {
+	case SortYear:
+		cursor.Value = strconv.Itoa(movie.Year)
+	case SortRating:
+		cursor.Value = strconv.FormatFloat(float64(movie.Rating), 'g', -1, 64)
+	case SortCreatedAt:
+		cursor.Value = movie.CreatedAt.Format(time.RFC3339Nano)
+	case SortUpdatedAt:
+		cursor.Value = movie.UpdatedAt.Format(time.RFC3339Nano)
+	default:
+		cursor.Value = movie.SortKey
+	}
+	return cursor
+}
+
+// SortField liefert das wirksame Sortierfeld (Standard: Titel)
+func (f MovieFilter) SortField() string {
+	if f.Sort == "" {
+		return SortTitle
+	}
+	return f.Sort
+}
+
+// SortOrder liefert die wirksame Richtung (Standard: aufsteigend)
+func (f MovieFilter) SortOrder() string {
+	if f.Order == "" {
+		return "asc"
+	}
+	return f.Order
+}
@@ -1,11 +1,17 @@
 package models
 
 // PaginationMeta enthält Metadaten für paginierte Antworten
+// Page und TotalPages fehlen im Cursor-Modus, Total und TotalPages bei include_total=false
 type PaginationMeta struct {
 	Page       int   `json:"page"`
 	Limit      int   `json:"limit"`
 	Total      int64 `json:"total"`
 	TotalPages int   `json:"total_pages"`
+	// Nur im Cursor-Modus; fehlt, wenn es keine weitere Seite gibt
+	NextCursor string `json:"next_cursor,omitempty" example:"eyJzIjoidGl0bGUiLCJvIjoiYXNjIiwidiI6IkFsaWVuIiwiaWQiOjQyfQ"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	// Nur bei GET /movies; fehlt bei include_total=false
+	Facets *MovieFacets `json:"facets,omitempty"`
 }
 
 // PaginatedResponse ist die Standardstruktur für paginierte Antworten
@@ -13,3 +19,15 @@ type PaginatedResponse struct {
 	Data []Movie        `json:"data"`
 	Meta PaginationMeta `json:"meta"`
 }
+
+// ScoredMovie ist ein Treffer der unscharfen Suche mit seiner Ähnlichkeit (0 bis 1)
+type ScoredMovie struct {
+	Movie
+	Score float64 `json:"score" example:"0.9"`
+}
+
+// FuzzySearchResponse ist die Antwort von GET /movies/search?fuzzy=true
+type FuzzySearchResponse struct {
+	Data []ScoredMovie  `json:"data"`
+	Meta PaginationMeta `json:"meta"`
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// RevocationEntry speichert den Widerrufsstatus von Refresh-Tokens, wenn kein Redis verfügbar ist
+// Ein ExpiresAt von nil bedeutet, dass der Eintrag nicht abläuft.
+type RevocationEntry struct {
+	Key       string     `gorm:"primaryKey;size:191"`
+	Value     int64      `gorm:"not null"`
+	ExpiresAt *time.Time `gorm:"index"`
+}
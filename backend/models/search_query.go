@@ -0,0 +1,330 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Felder der Suchsprache
+const (
+	QueryFieldYear     = "year"
+	QueryFieldRating   = "rating"
+	QueryFieldRuntime  = "runtime"
+	QueryFieldDiscs    = "discs"
+	QueryFieldFormat   = "format"
+	QueryFieldRegion   = "region"
+	QueryFieldStatus   = "status"
+	QueryFieldEdition  = "edition"
+	QueryFieldTitle    = "title"
+	QueryFieldGenre    = "genre"
+	QueryFieldDirector = "director"
+	QueryFieldActor    = "actor"
+)
+
+// Vergleichsoperatoren für Zahlenfelder
+const (
+	QueryOpEqual        = "="
+	QueryOpGreater      = ">"
+	QueryOpGreaterEqual = ">="
+	QueryOpLess         = "<"
+	QueryOpLessEqual    = "<="
+	QueryOpRange        = ".."
+)
+
+var numericQueryFields = map[string]bool{
+	QueryFieldYear:    true,
+	QueryFieldRating:  true,
+	QueryFieldRuntime: true,
+	QueryFieldDiscs:   true,
+}
+
+var textQueryFields = map[string]bool{
+	QueryFieldFormat:   true,
+	QueryFieldRegion:   true,
+	QueryFieldStatus:   true,
+	QueryFieldEdition:  true,
+	QueryFieldTitle:    true,
+	QueryFieldGenre:    true,
+	QueryFieldDirector: true,
+	QueryFieldActor:    true,
+}
+
+// Zahlenwerte der Suchsprache: Ziffern mit optionalen Nachkommastellen
+var numberPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+// Alternative Feldnamen
+var queryFieldAliases = map[string]string{
+	"cast": QueryFieldActor,
+	"disc": QueryFieldDiscs,
+}
+
+// Zulässige Werte der Aufzählungsfelder, identisch mit den Binding-Regeln von Movie
+var queryFieldValues = map[string][]string{
+	QueryFieldFormat: {FormatDVD, FormatBluray, FormatUHD, FormatDigital},
+	QueryFieldRegion: {"0", "1", "2", "3", "4", "5", "6", "7", "8", "A", "B", "C", RegionFree},
+	QueryFieldStatus: {StatusOwned, StatusWishlist, StatusPreordered},
+}
+
+// QueryTerm ist eine Bedingung der Suchsprache, z. B. year:1990..1999 oder -horror
+type QueryTerm struct {
+	// Field ist leer für Freitext
+	Field  string
+	Negate bool
+	// Text ist der Wert von Freitext und Textfeldern
+	Text string
+	// Op, Min und Max beschreiben den Vergleich bei Zahlenfeldern; > und >= setzen nur Min, < und <= nur Max
+	Op     string
+	Min    float64
+	Max    float64
+	HasMin bool
+	HasMax bool
+}
+
+// SearchQuery ist der geparste Suchbegriff von GET /movies/search
+type SearchQuery struct {
+	Terms []QueryTerm
+}
+
+// FreeText liefert die nicht negierten Freitextteile als einen Suchbegriff
+func (q SearchQuery) FreeText() string {
+	var parts []string
+	for _, term := range q.Terms {
+		if term.Field == "" && !term.Negate {
+			parts = append(parts, term.Text)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// Conditions liefert alle Bedingungen außer dem positiven Freitext
+func (q SearchQuery) Conditions() []QueryTerm {
+	var conditions []QueryTerm
+	for _, term := range q.Terms {
+		if term.Field != "" || term.Negate {
+			conditions = append(conditions, term)
+		}
+	}
+	return conditions
+}
+
+// QuerySyntaxError beschreibt einen Fehler im Suchbegriff
+// Position ist der 0-basierte Zeichenindex im Suchbegriff.
+type QuerySyntaxError struct {
+	Position int
+	Message  string
+}
+
+func (e *QuerySyntaxError) Error() string {
+	return fmt.Sprintf("Syntaxfehler an Position %d: %s", e.Position, e.Message)
+}
+
+// ParseSearchQuery zerlegt einen Suchbegriff wie
+//
+//	year:1990..1999 rating:>7 format:bluray director:"Ridley Scott" -horror
+//
+// in einzelne Bedingungen. Wörter ohne bekanntes Feld sind Freitext, auch mit Doppelpunkt wie "Re:Zero";
+// ein führendes "-" negiert.
+func ParseSearchQuery(input string) (SearchQuery, error) {
+	p := queryParser{input: []rune(input)}
+	var query SearchQuery
+	for {
+		p.skipSpaces()
+		if p.done() {
+			return query, nil
+		}
+		term, err := p.term()
+		if err != nil {
+			return SearchQuery{}, err
+		}
+		query.Terms = append(query.Terms, term)
+	}
+}
+
+type queryParser struct {
+	input []rune
+	pos   int
+}
+
+func (p *queryParser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *queryParser) skipSpaces() {
+	for !p.done() && unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *queryParser) errorAt(pos int, format string, args ...interface{}) error {
+	return &QuerySyntaxError{Position: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *queryParser) term() (QueryTerm, error) {
+	var term QueryTerm
+	// Ein alleinstehender Bindestrich wie in "Krieg - Frieden" bleibt Freitext
+	if p.input[p.pos] == '-' && p.pos+1 < len(p.input) && !unicode.IsSpace(p.input[p.pos+1]) {
+		term.Negate = true
+		p.pos++
+	}
+
+	start := p.pos
+	if p.input[p.pos] == '"' {
+		text, err := p.quoted()
+		if err != nil {
+			return term, err
+		}
+		term.Text = text
+		return term, nil
+	}
+
+	// Ein Feld besteht aus Buchstaben und Unterstrichen, gefolgt von ":" und einem Wert
+	for !p.done() && (unicode.IsLetter(p.input[p.pos]) || p.input[p.pos] == '_') {
+		p.pos++
+	}
+	if !p.done() && p.input[p.pos] == ':' && p.pos > start && p.pos+1 < len(p.input) && !unicode.IsSpace(p.input[p.pos+1]) {
+		name := strings.ToLower(string(p.input[start:p.pos]))
+		if alias, ok := queryFieldAliases[name]; ok {
+			name = alias
+		}
+		// Unbekannte Präfixe wie in "Re:Zero" oder "Mission:Impossible" gehören zum Titel
+		if numericQueryFields[name] || textQueryFields[name] {
+			p.pos++
+			term.Field = name
+			if numericQueryFields[name] {
+				return term, p.numeric(&term)
+			}
+			return term, p.text(&term)
+		}
+	}
+
+	// Freitext bis zum nächsten Leerzeichen, z. B. "Mission:", "Re:Zero" oder "2001"
+	p.pos = start
+	term.Text = p.word()
+	return term, nil
+}
+
+func (p *queryParser) word() string {
+	start := p.pos
+	for !p.done() && !unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+	return string(p.input[start:p.pos])
+}
+
+func (p *queryParser) quoted() (string, error) {
+	start := p.pos
+	p.pos++
+	for !p.done() && p.input[p.pos] != '"' {
+		p.pos++
+	}
+	if p.done() {
+		return "", p.errorAt(start, "Anführungszeichen wird nicht geschlossen")
+	}
+	text := string(p.input[start+1 : p.pos])
+	p.pos++
+	if strings.TrimSpace(text) == "" {
+		return "", p.errorAt(start, "Leerer Suchbegriff in Anführungszeichen")
+	}
+	if !p.done() && !unicode.IsSpace(p.input[p.pos]) {
+		return "", p.errorAt(p.pos, "Nach dem Anführungszeichen wird ein Leerzeichen erwartet")
+	}
+	return text, nil
+}
+
+func (p *queryParser) text(term *QueryTerm) error {
+	start := p.pos
+	var value string
+	if p.input[p.pos] == '"' {
+		quoted, err := p.quoted()
+		if err != nil {
+			return err
+		}
+		value = quoted
+	} else {
+		value = p.word()
+	}
+
+	if allowed, ok := queryFieldValues[term.Field]; ok {
+		for _, candidate := range allowed {
+			if strings.EqualFold(candidate, value) {
+				term.Text = candidate
+				return nil
+			}
+		}
+		return p.errorAt(start, "Ungültiger Wert %q für %s (erlaubt: %s)", value, term.Field, strings.Join(allowed, ", "))
+	}
+	term.Text = value
+	return nil
+}
+
+func (p *queryParser) numeric(term *QueryTerm) error {
+	start := p.pos
+	value := p.word()
+
+	switch {
+	case strings.Contains(value, ".."):
+		parts := strings.SplitN(value, "..", 2)
+		term.Op = QueryOpRange
+		if parts[0] == "" && parts[1] == "" {
+			return p.errorAt(start, "Bereich ohne Grenzen")
+		}
+		if parts[0] != "" {
+			min, err := p.number(parts[0], start)
+			if err != nil {
+				return err
+			}
+			term.Min, term.HasMin = min, true
+		}
+		if parts[1] != "" {
+			max, err := p.number(parts[1], start+len([]rune(parts[0]))+2)
+			if err != nil {
+				return err
+			}
+			term.Max, term.HasMax = max, true
+		}
+		if term.HasMin && term.HasMax && term.Min > term.Max {
+			return p.errorAt(start, "Untergrenze ist größer als Obergrenze")
+		}
+		return nil
+	default:
+		op := ""
+		for _, candidate := range []string{QueryOpGreaterEqual, QueryOpLessEqual, QueryOpGreater, QueryOpLess} {
+			if strings.HasPrefix(value, candidate) {
+				op = candidate
+				break
+			}
+		}
+		number, err := p.number(strings.TrimPrefix(value, op), start+len(op))
+		if err != nil {
+			return err
+		}
+		switch op {
+		case "":
+			term.Op = QueryOpEqual
+			term.Min, term.HasMin = number, true
+			term.Max, term.HasMax = number, true
+		case QueryOpGreater, QueryOpGreaterEqual:
+			term.Op = op
+			term.Min, term.HasMin = number, true
+		default:
+			term.Op = op
+			term.Max, term.HasMax = number, true
+		}
+		return nil
+	}
+}
+
+// number liest eine Dezimalzahl wie 7 oder 7.5; ParseFloat allein ließe auch NaN, Inf und 1e3 zu
+func (p *queryParser) number(value string, pos int) (float64, error) {
+	if !numberPattern.MatchString(value) {
+		return 0, p.errorAt(pos, "Ungültige Zahl %q", value)
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, p.errorAt(pos, "Ungültige Zahl %q", value)
+	}
+	return number, nil
+}
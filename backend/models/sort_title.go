@@ -0,0 +1,78 @@
+package models
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// SortArticles sind die führenden Artikel je Sprache, die beim Ableiten des Sortiertitels entfallen
+// Artikel mit Apostroph wie "l'" werden ohne folgendes Leerzeichen erkannt.
+var SortArticles = map[string][]string{
+	"de": {"der", "die", "das", "ein", "eine"},
+	"en": {"the", "a", "an"},
+	"es": {"el", "la", "los", "las"},
+	"fr": {"le", "la", "les", "l'"},
+	"it": {"il", "lo", "la", "i", "gli", "le", "l'"},
+}
+
+// DefaultSortTitleLanguages sind die Sprachen, deren Artikel ohne Konfiguration entfallen
+const DefaultSortTitleLanguages = "de,en"
+
+// Artikel der aktiven Sprachen, längere zuerst ("les" vor "le")
+var sortArticles = articlesFor(strings.Split(DefaultSortTitleLanguages, ","))
+
+// SortTitleLanguages liest die Sprachen für die Sortiertitel aus SORT_TITLE_LANGUAGES (z. B. "de,en,fr")
+func SortTitleLanguages() []string {
+	value := strings.TrimSpace(os.Getenv("SORT_TITLE_LANGUAGES"))
+	if value == "" {
+		value = DefaultSortTitleLanguages
+	}
+	var languages []string
+	for _, language := range strings.Split(value, ",") {
+		if language = strings.ToLower(strings.TrimSpace(language)); language != "" {
+			languages = append(languages, language)
+		}
+	}
+	return languages
+}
+
+// SetSortTitleLanguages legt fest, welche Artikel DeriveSortTitle entfernt
+// Unbekannte Sprachen führen zu einem Fehler; die bisherige Auswahl bleibt dann erhalten.
+func SetSortTitleLanguages(languages []string) error {
+	for _, language := range languages {
+		if _, ok := SortArticles[language]; !ok {
+			return fmt.Errorf("Unbekannte Sprache für Sortiertitel: %q", language)
+		}
+	}
+	sortArticles = articlesFor(languages)
+	return nil
+}
+
+func articlesFor(languages []string) []string {
+	var articles []string
+	for _, language := range languages {
+		articles = append(articles, SortArticles[language]...)
+	}
+	sort.SliceStable(articles, func(i, j int) bool {
+		return len(articles[i]) > len(articles[j])
+	})
+	return articles
+}
+
+// DeriveSortTitle entfernt einen führenden Artikel aus einem Titel, z. B. "The Matrix" -> "Matrix"
+// Titel, die nur aus dem Artikel bestehen, bleiben unverändert.
+func DeriveSortTitle(title string) string {
+	title = strings.TrimSpace(title)
+	for _, article := range sortArticles {
+		prefix := article
+		if !strings.HasSuffix(article, "'") {
+			prefix += " "
+		}
+		if len(title) > len(prefix) && strings.EqualFold(title[:len(prefix)], prefix) {
+			return strings.TrimSpace(title[len(prefix):])
+		}
+	}
+	return title
+}
@@ -10,3 +10,9 @@ type SwaggerResponse struct {
 type ErrorResponse struct {
 	Error string `json:"error" example:"error message"`
 }
+
+// QueryErrorResponse represents a search query syntax error with its position
+type QueryErrorResponse struct {
+	Error    string `json:"error" example:"Syntaxfehler an Position 5: Ungültige Zahl \"199x\""`
+	Position int    `json:"position" example:"5"`
+}
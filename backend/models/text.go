@@ -0,0 +1,46 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"gorm.io/gorm"
+)
+
+// Buchstaben, die sich nicht in Grundbuchstabe und Akzent zerlegen lassen
+var foldReplacer = strings.NewReplacer(
+	"ß", "ss",
+	"æ", "ae",
+	"œ", "oe",
+	"ø", "o",
+	"ł", "l",
+	"đ", "d",
+	"þ", "th",
+)
+
+// FoldText wandelt einen Text für Vergleiche in Kleinbuchstaben ohne Akzente um, z. B. "Amélie" in "amelie"
+func FoldText(s string) string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), strings.ToLower(s))
+	if err != nil {
+		folded = strings.ToLower(s)
+	}
+	return foldReplacer.Replace(folded)
+}
+
+// BeforeSave hält den gefalteten Suchtitel und den abgeleiteten Sortiertitel aktuell
+// Ein eigener Sortiertitel (SortTitleCustom) bleibt erhalten. Teilaktualisierungen ohne Titel
+// (z. B. über Assoziationen) lassen beide unverändert.
+func (m *Movie) BeforeSave(tx *gorm.DB) error {
+	if m.Title != "" {
+		m.SearchTitle = FoldText(m.Title)
+		if !m.SortTitleCustom || m.SortTitle == "" {
+			m.SortTitle = DeriveSortTitle(m.Title)
+			m.SortTitleCustom = false
+		}
+		m.SortKey = FoldText(m.SortTitle)
+	}
+	return nil
+}
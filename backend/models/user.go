@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// User ist ein Benutzerkonto der Filmsammlung
+type User struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	Username     string    `json:"username" gorm:"uniqueIndex;not null"`
+	PasswordHash string    `json:"-" gorm:"not null"`
+	Role         string    `json:"role" gorm:"not null;default:viewer"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Credentials sind die Anmeldedaten für Registrierung und Login
+type Credentials struct {
+	Username string `json:"username" binding:"required,min=3,max=64"`
+	Password string `json:"password" binding:"required,min=8,max=72"`
+}
+
+// RoleUpdate ist der Request-Body zum Ändern der Rolle eines Benutzers
+type RoleUpdate struct {
+	Role string `json:"role" binding:"required,oneof=viewer editor admin"`
+}
@@ -0,0 +1,12 @@
+package models
+
+// Status eines Films in der Sammlung
+// Filme auf der Wunschliste oder in Vorbestellung gehören noch nicht zum Bestand.
+const (
+	StatusOwned      = "owned"
+	StatusWishlist   = "wishlist"
+	StatusPreordered = "preordered"
+)
+
+// StatusAll hebt im Filter die Einschränkung auf den Bestand auf
+const StatusAll = "all"
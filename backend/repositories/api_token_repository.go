@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"gorm.io/gorm"
+)
+
+type APITokenRepository struct {
+	db *gorm.DB
+}
+
+func NewAPITokenRepository(db *gorm.DB) *APITokenRepository {
+	return &APITokenRepository{db: db}
+}
+
+func (r *APITokenRepository) Create(token *models.APIToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *APITokenRepository) ListByUser(userID uint) ([]models.APIToken, error) {
+	var tokens []models.APIToken
+	result := r.db.Where("user_id = ?", userID).Order("id").Find(&tokens)
+	return tokens, result.Error
+}
+
+func (r *APITokenRepository) GetByHash(hash string) (models.APIToken, error) {
+	var token models.APIToken
+	result := r.db.Where("token_hash = ?", hash).First(&token)
+	return token, result.Error
+}
+
+func (r *APITokenRepository) TouchLastUsed(id uint, at time.Time) error {
+	return r.db.Model(&models.APIToken{}).Where("id = ?", id).Update("last_used_at", at).Error
+}
+
+func (r *APITokenRepository) Delete(userID, id uint) error {
+	result := r.db.Where("user_id = ?", userID).Delete(&models.APIToken{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
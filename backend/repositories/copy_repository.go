@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"gorm.io/gorm"
+)
+
+type CopyRepository struct {
+	db *gorm.DB
+}
+
+func NewCopyRepository(db *gorm.DB) *CopyRepository {
+	return &CopyRepository{db: db}
+}
+
+func (r *CopyRepository) ListByMovie(movieID uint) ([]models.Copy, error) {
+	var copies []models.Copy
+	result := r.db.Where("movie_id = ?", movieID).Preload("Location").Order("id").Find(&copies)
+	return copies, result.Error
+}
+
+func (r *CopyRepository) GetByID(movieID, id uint) (models.Copy, error) {
+	var item models.Copy
+	result := r.db.Where("movie_id = ?", movieID).Preload("Location").First(&item, id)
+	return item, result.Error
+}
+
+func (r *CopyRepository) Create(item *models.Copy) error {
+	return r.db.Create(item).Error
+}
+
+func (r *CopyRepository) Update(item *models.Copy) error {
+	return r.db.Save(item).Error
+}
+
+func (r *CopyRepository) Delete(movieID, id uint) error {
+	result := r.db.Where("movie_id = ?", movieID).Delete(&models.Copy{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package repositories
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"gorm.io/gorm"
+)
+
+// Mindestähnlichkeit unscharfer Treffer, gleich für Editierdistanz und pg_trgm
+const fuzzyMinScore = 0.6
+
+// Ähnlichkeit auf PostgreSQL: Gesamttitel oder bestes Wortfenster (pg_trgm)
+const trigramScore = "GREATEST(similarity(search_title, ?), word_similarity(?, search_title))"
+
+type scoredID struct {
+	ID    uint
+	Score float64
+}
+
+// FuzzySearch vergleicht den Freitext unscharf mit den Titeln und liefert die Treffer mit ihrer Ähnlichkeit, beste zuerst
+// Mit pg_trgm übernimmt PostgreSQL den Vergleich, sonst wird die Editierdistanz im Prozess berechnet.
+// Akzente und Groß-/Kleinschreibung werden in beiden Fällen ignoriert, Feldbedingungen schränken zusätzlich ein.
+func (r *MovieRepository) FuzzySearch(ownerID uint, query models.SearchQuery, filter models.MovieFilter, offset, limit int) ([]models.ScoredMovie, int64, error) {
+	folded := models.FoldText(strings.TrimSpace(query.FreeText()))
+
+	var hits []scoredID
+	var total int64
+	var err error
+	if r.trigram {
+		hits, total, err = r.trigramSearch(ownerID, folded, query, filter, offset, limit)
+	} else {
+		hits, total, err = r.editDistanceSearch(ownerID, folded, query, filter, offset, limit)
+	}
+	if err != nil || len(hits) == 0 {
+		return []models.ScoredMovie{}, total, err
+	}
+
+	ids := make([]uint, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ID
+	}
+	var movies []models.Movie
+	if err := r.db.Preload("Location").Preload("Copies.Location").Where("id IN ?", ids).Find(&movies).Error; err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[uint]models.Movie, len(movies))
+	for _, movie := range movies {
+		byID[movie.ID] = movie
+	}
+
+	results := make([]models.ScoredMovie, 0, len(hits))
+	for _, hit := range hits {
+		if movie, ok := byID[hit.ID]; ok {
+			results = append(results, models.ScoredMovie{Movie: movie, Score: hit.Score})
+		}
+	}
+	return results, total, nil
+}
+
+func (r *MovieRepository) trigramSearch(ownerID uint, folded string, query models.SearchQuery, filter models.MovieFilter, offset, limit int) ([]scoredID, int64, error) {
+	var hits []scoredID
+	var total int64
+
+	// Die Operatoren % und <% nutzen den Trigramm-Index, filtern aber nur mit pg_trgm.similarity_threshold
+	// (Standard 0,3); die Ähnlichkeit selbst begrenzt die Treffer wie bei der Editierdistanz
+	candidates := r.db.Model(&models.Movie{}).Scopes(ownedBy(ownerID), matching(filter), querying(r.db, query)).
+		Where("(search_title % ? OR ? <% search_title)", folded, folded).
+		Where(trigramScore+" >= ?", folded, folded, fuzzyMinScore)
+	if err := candidates.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := candidates.Select("id, "+trigramScore+" AS score", folded, folded).
+		Order("score DESC").Order("id").Offset(offset).Limit(limit).Scan(&hits)
+	return hits, total, result.Error
+}
+
+func (r *MovieRepository) editDistanceSearch(ownerID uint, folded string, query models.SearchQuery, filter models.MovieFilter, offset, limit int) ([]scoredID, int64, error) {
+	var candidates []struct {
+		ID          uint
+		SearchTitle string
+	}
+	if err := r.db.Model(&models.Movie{}).Scopes(ownedBy(ownerID), matching(filter), querying(r.db, query)).
+		Select("id, search_title").Scan(&candidates).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var hits []scoredID
+	for _, candidate := range candidates {
+		if score := titleSimilarity(folded, candidate.SearchTitle); score >= fuzzyMinScore {
+			hits = append(hits, scoredID{ID: candidate.ID, Score: score})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ID < hits[j].ID
+	})
+
+	total := int64(len(hits))
+	if offset >= len(hits) {
+		return nil, total, nil
+	}
+	end := offset + limit
+	if end > len(hits) {
+		end = len(hits)
+	}
+	return hits[offset:end], total, nil
+}
+
+// titleSimilarity vergleicht die Suche mit dem ganzen Titel und mit jedem gleich langen Wortfenster,
+// damit "termintor" auch "the terminator" findet
+func titleSimilarity(query, title string) float64 {
+	if query == "" || title == "" {
+		return 0
+	}
+	if strings.Contains(title, query) {
+		return 1
+	}
+
+	best := similarity(query, title)
+	queryWords := len(strings.Fields(query))
+	words := strings.Fields(title)
+	for i := 0; i+queryWords <= len(words); i++ {
+		if score := similarity(query, strings.Join(words[i:i+queryWords], " ")); score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+// similarity berechnet 1 - Editierdistanz / Länge des längeren Textes
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// BackfillSearchTitles setzt den gefalteten Suchtitel für Filme, die vor seiner Einführung angelegt wurden
+func (r *MovieRepository) BackfillSearchTitles() error {
+	var movies []models.Movie
+	if err := r.db.Select("id, title").Where("search_title = '' OR search_title IS NULL").Find(&movies).Error; err != nil {
+		return err
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, movie := range movies {
+			if err := tx.Model(&models.Movie{}).Where("id = ?", movie.ID).UpdateColumn("search_title", models.FoldText(movie.Title)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
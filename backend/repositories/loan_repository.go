@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"gorm.io/gorm"
+)
+
+// activeLoan trifft auf Ausleihen zu, die noch nicht zurückgegeben wurden
+const activeLoan = "returned_on = '' OR returned_on IS NULL"
+
+type LoanRepository struct {
+	db *gorm.DB
+}
+
+func NewLoanRepository(db *gorm.DB) *LoanRepository {
+	return &LoanRepository{db: db}
+}
+
+func (r *LoanRepository) Create(loan *models.Loan) error {
+	return r.db.Create(loan).Error
+}
+
+func (r *LoanRepository) Update(loan *models.Loan) error {
+	return r.db.Save(loan).Error
+}
+
+func (r *LoanRepository) GetActiveByMovie(movieID uint) (models.Loan, error) {
+	var loan models.Loan
+	result := r.db.Where("movie_id = ?", movieID).Where(activeLoan).First(&loan)
+	return loan, result.Error
+}
+
+// ListByMovie liefert die Ausleihhistorie eines Films, neueste zuerst
+func (r *LoanRepository) ListByMovie(movieID uint) ([]models.Loan, error) {
+	var loans []models.Loan
+	result := r.db.Where("movie_id = ?", movieID).Order("lent_on DESC, id DESC").Find(&loans)
+	return loans, result.Error
+}
+
+// ListActive liefert alle verliehenen Filme, nach Fälligkeit sortiert
+func (r *LoanRepository) ListActive(ownerID uint) ([]models.Loan, error) {
+	var loans []models.Loan
+	result := r.db.Scopes(ownedBy(ownerID)).Where(activeLoan).Preload("Movie").Order("due_on, lent_on").Find(&loans)
+	return loans, result.Error
+}
+
+// ListOverdue liefert alle verliehenen Filme, deren Fälligkeitsdatum vor today liegt
+func (r *LoanRepository) ListOverdue(ownerID uint, today string) ([]models.Loan, error) {
+	var loans []models.Loan
+	result := r.db.Scopes(ownedBy(ownerID)).Where(activeLoan).
+		Where("due_on <> '' AND due_on < ?", today).
+		Preload("Movie").Order("due_on, lent_on").Find(&loans)
+	return loans, result.Error
+}
@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"gorm.io/gorm"
+)
+
+type LocationRepository struct {
+	db *gorm.DB
+}
+
+func NewLocationRepository(db *gorm.DB) *LocationRepository {
+	return &LocationRepository{db: db}
+}
+
+func (r *LocationRepository) List(ownerID uint) ([]models.Location, error) {
+	var locations []models.Location
+	result := r.db.Scopes(ownedBy(ownerID)).Order("room, shelf, slot").Find(&locations)
+	return locations, result.Error
+}
+
+func (r *LocationRepository) GetByID(ownerID, id uint) (models.Location, error) {
+	var location models.Location
+	result := r.db.Scopes(ownedBy(ownerID)).First(&location, id)
+	return location, result.Error
+}
+
+func (r *LocationRepository) Create(location *models.Location) error {
+	return r.db.Create(location).Error
+}
+
+func (r *LocationRepository) Update(location *models.Location) error {
+	return r.db.Save(location).Error
+}
+
+// Delete löscht einen Lagerort; Filme und Exemplare an diesem Ort verlieren ihre Zuordnung
+func (r *LocationRepository) Delete(ownerID, id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Scopes(ownedBy(ownerID)).Delete(&models.Location{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Model(&models.Movie{}).Where("location_id = ?", id).Update("location_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Copy{}).Where("location_id = ?", id).Update("location_id", nil).Error
+	})
+}
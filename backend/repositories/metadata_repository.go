@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MetadataRepository speichert Genres, Personen und Credits aus den TMDB-Details
+type MetadataRepository struct {
+	db *gorm.DB
+}
+
+func NewMetadataRepository(db *gorm.DB) *MetadataRepository {
+	return &MetadataRepository{db: db}
+}
+
+// ReplaceMovieMetadata ersetzt Laufzeit, Genres und Credits eines Films in einer Transaktion
+// Genres und Personen werden über ihre TMDB-ID wiederverwendet, damit sie filmübergreifend eindeutig bleiben.
+func (r *MetadataRepository) ReplaceMovieMetadata(movieID uint, runtime int, genres []models.Genre, credits []models.Credit) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Movie{}).Where("id = ?", movieID).Update("runtime", runtime).Error; err != nil {
+			return err
+		}
+
+		for i := range genres {
+			if err := upsertGenre(tx, &genres[i]); err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&models.Movie{ID: movieID}).Association("Genres").Replace(genres); err != nil {
+			return err
+		}
+
+		if err := tx.Where("movie_id = ?", movieID).Delete(&models.Credit{}).Error; err != nil {
+			return err
+		}
+		for i := range credits {
+			if err := upsertPerson(tx, credits[i].Person); err != nil {
+				return err
+			}
+			credits[i].ID = 0
+			credits[i].MovieID = movieID
+			credits[i].PersonID = credits[i].Person.ID
+		}
+		if len(credits) == 0 {
+			return nil
+		}
+		return tx.Omit("Person").Create(&credits).Error
+	})
+}
+
+func (r *MetadataRepository) GetPerson(id uint) (models.Person, error) {
+	var person models.Person
+	result := r.db.First(&person, id)
+	return person, result.Error
+}
+
+func (r *MetadataRepository) GetGenre(id uint) (models.Genre, error) {
+	var genre models.Genre
+	result := r.db.First(&genre, id)
+	return genre, result.Error
+}
+
+// ListGenres liefert alle Genres, die in der Sammlung vorkommen, mit der Anzahl ihrer Filme
+func (r *MetadataRepository) ListGenres(ownerID uint, filter models.MovieFilter) ([]models.GenreCount, error) {
+	var genres []models.GenreCount
+	movies := r.db.Model(&models.Movie{}).Select("id").Scopes(ownedBy(ownerID), matching(filter))
+	result := r.db.Table("genres").
+		Select("genres.id, genres.tmdb_id, genres.name, COUNT(movie_genres.movie_id) AS movie_count").
+		Joins("JOIN movie_genres ON movie_genres.genre_id = genres.id").
+		Where("movie_genres.movie_id IN (?)", movies).
+		Group("genres.id, genres.tmdb_id, genres.name").
+		Order("genres.name").
+		Scan(&genres)
+	return genres, result.Error
+}
+
+// upsertGenre legt ein Genre an oder aktualisiert dessen Namen und lädt die lokale ID
+func upsertGenre(tx *gorm.DB, genre *models.Genre) error {
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tmdb_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name"}),
+	}).Create(genre).Error; err != nil {
+		return err
+	}
+	return tx.Where("tmdb_id = ?", genre.TMDBId).First(genre).Error
+}
+
+// upsertPerson legt eine Person an oder aktualisiert Name und Profilbild und lädt die lokale ID
+func upsertPerson(tx *gorm.DB, person *models.Person) error {
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tmdb_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "profile_path"}),
+	}).Create(person).Error; err != nil {
+		return err
+	}
+	return tx.Where("tmdb_id = ?", person.TMDBId).First(person).Error
+}
@@ -1,84 +1,348 @@
 package repositories
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/MichaelKlank/movie-collector/backend/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type MovieRepository struct {
 	db *gorm.DB
+	// Textsuchkonfiguration von PostgreSQL; leer, solange die Volltextsuche nicht aktiviert ist
+	searchConfig string
+	// Unscharfe Suche über pg_trgm statt Editierdistanz im Prozess
+	trigram bool
 }
 
 func NewMovieRepository(db *gorm.DB) *MovieRepository {
 	return &MovieRepository{db: db}
 }
 
-func (r *MovieRepository) GetAll() ([]models.Movie, error) {
-	var movies []models.Movie
-	result := r.db.Find(&movies)
-	return movies, result.Error
+// EnableFullTextSearch schaltet SearchMovies auf PostgreSQL auf die Spalte search_vector um
+// Voraussetzung ist db.EnsureFullTextSearch mit derselben Konfiguration. Andere Datenbanken behalten die LIKE-Suche.
+func (r *MovieRepository) EnableFullTextSearch(config string) {
+	if r.db.Dialector.Name() == "postgres" {
+		r.searchConfig = config
+	}
 }
 
-// GetPaginated ruft eine paginierte Liste von Filmen ab
-// offset: Anzahl der zu überspringenden Einträge
-// limit: Maximale Anzahl der zurückzugebenden Einträge
-// Gibt die Filmliste, die Gesamtanzahl der Filme und einen etwaigen Fehler zurück
-func (r *MovieRepository) GetPaginated(offset, limit int) ([]models.Movie, int64, error) {
+// EnableTrigramSearch lässt FuzzySearch auf PostgreSQL die Ähnlichkeit per pg_trgm berechnen
+// Voraussetzung ist db.EnsureTrigramSearch.
+func (r *MovieRepository) EnableTrigramSearch() {
+	r.trigram = r.db.Dialector.Name() == "postgres"
+}
+
+// ownedBy schränkt eine Abfrage auf die Datensätze eines Benutzers ein
+// ownerID 0 steht für anonyme Anfragen und sieht nur Datensätze ohne Besitzer, also Altbestand
+// aus der Zeit vor den Benutzerkonten, nie die Sammlung eines Benutzers.
+func ownedBy(ownerID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if ownerID == 0 {
+			return db.Where("owner_id IS NULL")
+		}
+		return db.Where("owner_id = ?", ownerID)
+	}
+}
+
+// matching wendet die optionalen Filter auf eine Abfrage an
+func matching(filter models.MovieFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.Format != "" {
+			db = db.Where("format = ?", filter.Format)
+		}
+		if filter.Region != "" {
+			db = db.Where("region = ?", filter.Region)
+		}
+		if filter.Edition != "" {
+			db = db.Where("LOWER(edition) LIKE ?", "%"+strings.ToLower(filter.Edition)+"%")
+		}
+		if filter.DiscCount > 0 {
+			db = db.Where("disc_count = ?", filter.DiscCount)
+		}
+		if filter.MediaType != "" {
+			db = db.Where("media_type = ?", filter.MediaType)
+		}
+		if filter.Status != "" && filter.Status != models.StatusAll {
+			db = db.Where("status = ?", filter.Status)
+		}
+		if filter.YearFrom > 0 {
+			db = db.Where("year >= ?", filter.YearFrom)
+		}
+		if filter.YearTo > 0 {
+			db = db.Where("year <= ?", filter.YearTo)
+		}
+		if filter.RatingMin > 0 {
+			db = db.Where("rating >= ?", filter.RatingMin)
+		}
+		if filter.HasImage != nil {
+			if *filter.HasImage {
+				db = db.Where("(image_path <> '' OR poster_path <> '')")
+			} else {
+				db = db.Where("(image_path = '' OR image_path IS NULL) AND (poster_path = '' OR poster_path IS NULL)")
+			}
+		}
+		if filter.TMDBLinked != nil {
+			if *filter.TMDBLinked {
+				db = db.Where("tmdb_id <> ''")
+			} else {
+				db = db.Where("(tmdb_id = '' OR tmdb_id IS NULL)")
+			}
+		}
+		if filter.StartsWith != "" {
+			letter := strings.ToLower(filter.StartsWith)
+			if letter == models.IndexOther {
+				db = db.Where("SUBSTR(sort_key, 1, 1) NOT IN ?", models.IndexLetters)
+			} else {
+				db = db.Where("SUBSTR(sort_key, 1, 1) = ?", letter)
+			}
+		}
+		return db
+	}
+}
+
+// sorted ordnet eine Abfrage nach filter.Sort und filter.Order; die ID sorgt für eine stabile Reihenfolge
+func sorted(filter models.MovieFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		column := sortColumn(filter.SortField())
+		desc := filter.SortOrder() == "desc"
+		return db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: desc}).
+			Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}, Desc: desc})
+	}
+}
+
+// sortColumn liefert die Spalte eines Sortierfelds; der Titel wird über den gefalteten Sortiertitel geordnet
+func sortColumn(sort string) string {
+	switch sort {
+	case models.SortYear, models.SortRating, models.SortCreatedAt, models.SortUpdatedAt:
+		return sort
+	}
+	return "sort_key"
+}
+
+// after schränkt eine nach cursor.Sort sortierte Abfrage auf die Filme hinter (bzw. vor) dem Cursor ein
+// Bei gleichem Sortierwert entscheidet die ID, damit keine Filme doppelt oder gar nicht erscheinen.
+func after(cursor models.Cursor) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		value, err := cursor.SortValue()
+		if err != nil {
+			_ = db.AddError(models.ErrInvalidCursor)
+			return db
+		}
+		column := sortColumn(cursor.Sort)
+		op := ">"
+		if (cursor.Order == "desc") != cursor.Backward {
+			op = "<"
+		}
+		return db.Where(fmt.Sprintf("(%s %s ? OR (%s = ? AND id %s ?))", column, op, column, op), value, value, cursor.ID)
+	}
+}
+
+// fetchPage zählt die Treffer einer Abfrage (außer bei page.SkipTotal) und lädt eine Seite davon
+// Ohne Cursor wird nach order sortiert und per Offset geblättert. Mit Cursor gilt immer die Sortierung
+// des Filters; die Seite beginnt hinter dem Cursor, und PageInfo enthält die Cursor der Nachbarseiten.
+// scopes werden nur auf die Seitenabfrage angewendet, z. B. für Preloads.
+func (r *MovieRepository) fetchPage(query *gorm.DB, filter models.MovieFilter, order func(*gorm.DB) *gorm.DB, page models.PageRequest, scopes ...func(*gorm.DB) *gorm.DB) ([]models.Movie, models.PageInfo, error) {
 	var movies []models.Movie
-	var total int64
+	info := models.PageInfo{Total: -1}
 
-	// Zähle die Gesamtanzahl der Filme
-	if err := r.db.Model(&models.Movie{}).Count(&total).Error; err != nil {
-		return nil, 0, err
+	if !page.SkipTotal {
+		if err := query.Count(&info.Total).Error; err != nil {
+			return nil, info, err
+		}
 	}
 
-	// Hole die paginierten Daten
-	result := r.db.Offset(offset).Limit(limit).Find(&movies)
-	if result.Error != nil {
-		return nil, 0, result.Error
+	if page.Cursor == nil {
+		result := query.Scopes(scopes...).Scopes(order).Offset(page.Offset).Limit(page.Limit).Find(&movies)
+		if result.Error != nil {
+			return nil, info, result.Error
+		}
+		return movies, info, nil
 	}
 
-	return movies, total, nil
+	// Rückwärts wird in umgekehrter Reihenfolge gelesen und das Ergebnis anschließend gedreht
+	cursor := *page.Cursor
+	direction := filter
+	if cursor.Backward {
+		direction.Order = "desc"
+		if filter.SortOrder() == "desc" {
+			direction.Order = "asc"
+		}
+	}
+	query = query.Scopes(scopes...).Scopes(sorted(direction))
+	if !cursor.IsStart() {
+		query = query.Scopes(after(cursor))
+	}
+	// Ein zusätzlicher Datensatz zeigt an, ob es in Leserichtung weitergeht
+	if err := query.Limit(page.Limit + 1).Find(&movies).Error; err != nil {
+		return nil, info, err
+	}
+	more := len(movies) > page.Limit
+	if more {
+		movies = movies[:page.Limit]
+	}
+	if cursor.Backward {
+		for i, j := 0, len(movies)-1; i < j; i, j = i+1, j-1 {
+			movies[i], movies[j] = movies[j], movies[i]
+		}
+	}
+	if len(movies) == 0 {
+		return movies, info, nil
+	}
+
+	first, last := movies[0], movies[len(movies)-1]
+	if cursor.Backward {
+		if more {
+			info.PrevCursor = models.CursorAt(filter, first, true).String()
+		}
+		info.NextCursor = models.CursorAt(filter, last, false).String()
+	} else {
+		if more {
+			info.NextCursor = models.CursorAt(filter, last, false).String()
+		}
+		if !cursor.IsStart() {
+			info.PrevCursor = models.CursorAt(filter, first, true).String()
+		}
+	}
+	return movies, info, nil
 }
 
-// SearchMovies sucht Filme basierend auf dem übergebenen Suchbegriff
-// Die Suche wird über mehrere Felder durchgeführt: Titel, Beschreibung, Jahr
-// query: Der Suchbegriff
-// offset: Anzahl der zu überspringenden Einträge
-// limit: Maximale Anzahl der zurückzugebenden Einträge
-// Gibt die gefundenen Filme, die Gesamtanzahl der Treffer und einen etwaigen Fehler zurück
-func (r *MovieRepository) SearchMovies(query string, offset, limit int) ([]models.Movie, int64, error) {
+// Facets zählt die gefilterten Filme pro Jahrzehnt und pro Bewertungsbereich
+func (r *MovieRepository) Facets(ownerID uint, filter models.MovieFilter) (models.MovieFacets, error) {
+	facets := models.MovieFacets{Decades: []models.DecadeFacet{}}
+
+	if err := r.db.Model(&models.Movie{}).Scopes(ownedBy(ownerID), matching(filter)).
+		Select("(year / 10) * 10 AS decade, COUNT(*) AS count").
+		Where("year > 0").
+		Group("decade").Order("decade").
+		Scan(&facets.Decades).Error; err != nil {
+		return facets, err
+	}
+
+	// Bereiche absteigend prüfen, damit jeder Film genau einem Bereich zugeordnet wird
+	bands := models.RatingBands
+	cases := make([]string, 0, len(bands))
+	for i := len(bands) - 1; i > 0; i-- {
+		cases = append(cases, fmt.Sprintf("WHEN rating >= %g THEN %d", bands[i].Min, i))
+	}
+	var counts []struct {
+		Band  int
+		Count int64
+	}
+	if err := r.db.Model(&models.Movie{}).Scopes(ownedBy(ownerID), matching(filter)).
+		Select("CASE " + strings.Join(cases, " ") + " ELSE 0 END AS band, COUNT(*) AS count").
+		Where("rating > 0").
+		Group("band").
+		Scan(&counts).Error; err != nil {
+		return facets, err
+	}
+
+	facets.Ratings = make([]models.RatingFacet, len(bands))
+	copy(facets.Ratings, bands)
+	for _, count := range counts {
+		facets.Ratings[count.Band].Count = count.Count
+	}
+	return facets, nil
+}
+
+func (r *MovieRepository) GetAll(ownerID uint) ([]models.Movie, error) {
 	var movies []models.Movie
-	var total int64
+	result := r.db.Scopes(ownedBy(ownerID)).Find(&movies)
+	return movies, result.Error
+}
 
-	// Bereite den Suchbegriff für das LIKE-Statement vor
-	searchTerm := "%" + strings.ToLower(query) + "%"
+// GetTitles lädt ID, Titel und Sortiertitel aller gefilterten Filme in der Reihenfolge der Liste, z. B. für den Alphabet-Index
+func (r *MovieRepository) GetTitles(ownerID uint, filter models.MovieFilter) ([]models.Movie, error) {
+	var movies []models.Movie
+	result := r.db.Model(&models.Movie{}).Select("id", "title", "sort_title", "sort_key").Scopes(ownedBy(ownerID), matching(filter), sorted(filter)).Find(&movies)
+	return movies, result.Error
+}
 
-	// Suche in mehreren Feldern
-	searchQuery := r.db.Model(&models.Movie{}).Where(
-		"LOWER(title) LIKE ? OR LOWER(description) LIKE ? OR LOWER(overview) LIKE ? OR CAST(year as TEXT) LIKE ?",
-		searchTerm, searchTerm, searchTerm, searchTerm,
-	)
+// GetPaginated ruft eine Seite der Filmliste ab
+// ownerID: Besitzer der Sammlung (0 = alle Filme)
+// filter: Optionale Filter
+// page: Offset und Limit oder ein Cursor; optional ohne Zählung
+// Gibt die Filmliste, die Gesamtanzahl (-1 ohne Zählung) mit den Nachbar-Cursorn und einen etwaigen Fehler zurück
+func (r *MovieRepository) GetPaginated(ownerID uint, filter models.MovieFilter, page models.PageRequest) ([]models.Movie, models.PageInfo, error) {
+	query := r.db.Model(&models.Movie{}).Scopes(ownedBy(ownerID), matching(filter))
+	return r.fetchPage(query, filter, sorted(filter), page, preloading("Location"))
+}
 
-	// Zähle die Gesamtanzahl der Treffer
-	if err := searchQuery.Count(&total).Error; err != nil {
-		return nil, 0, err
+// SearchMovies sucht Filme basierend auf dem geparsten Suchbegriff
+// Der Freitext wird über mehrere Felder gesucht: Titel, Beschreibung, Inhaltsangabe, Jahr.
+// Mit aktivierter Volltextsuche (PostgreSQL) werden Wortstämme gefunden und Treffer ohne
+// explizite Sortierung nach ts_rank geordnet; sonst wird per LIKE gesucht.
+// Im Cursor-Modus gilt immer die Sortierung des Filters, da sich der Rang nicht als Cursor eignet.
+// Feldbedingungen wie year:1990..1999 schränken zusätzlich ein.
+// ownerID: Besitzer der Sammlung (0 = alle Filme)
+// query: Der geparste Suchbegriff
+// filter: Optionale Filter
+// page: Offset und Limit oder ein Cursor; optional ohne Zählung
+// Gibt die gefundenen Filme, die Gesamtanzahl der Treffer (-1 ohne Zählung) mit den Nachbar-Cursorn und einen etwaigen Fehler zurück
+func (r *MovieRepository) SearchMovies(ownerID uint, query models.SearchQuery, filter models.MovieFilter, page models.PageRequest) ([]models.Movie, models.PageInfo, error) {
+	searchQuery := r.db.Model(&models.Movie{}).Scopes(ownedBy(ownerID), matching(filter), querying(r.db, query))
+	order := sorted(filter)
+
+	if text := query.FreeText(); text != "" {
+		// Bereite den Suchbegriff für das LIKE-Statement vor; Titel werden zusätzlich ohne Akzente verglichen
+		searchTerm := "%" + strings.ToLower(text) + "%"
+		foldedTerm := "%" + models.FoldText(text) + "%"
+
+		if r.searchConfig != "" {
+			// Titelteile wie "Termin" finden weiterhin "Terminator", die Rangfolge kommt aus dem Volltextindex
+			searchQuery = searchQuery.Where(
+				"search_vector @@ websearch_to_tsquery(?::regconfig, ?) OR search_title LIKE ? OR CAST(year as TEXT) LIKE ?",
+				r.searchConfig, text, foldedTerm, searchTerm,
+			)
+			if filter.Sort == "" {
+				order = rankedBy(r.searchConfig, text)
+			}
+		} else {
+			// Suche in mehreren Feldern
+			searchQuery = searchQuery.Where(
+				"search_title LIKE ? OR LOWER(description) LIKE ? OR LOWER(overview) LIKE ? OR CAST(year as TEXT) LIKE ?",
+				foldedTerm, searchTerm, searchTerm, searchTerm,
+			)
+		}
 	}
 
-	// Führe die Suche mit Paginierung durch
-	result := searchQuery.Offset(offset).Limit(limit).Find(&movies)
-	if result.Error != nil {
-		return nil, 0, result.Error
+	// Lagerorte von Film und Exemplaren mitladen, damit jeder Treffer zeigt, wo er steht
+	return r.fetchPage(searchQuery, filter, order, page, preloading("Location", "Copies.Location"))
+}
+
+// preloading lädt die angegebenen Assoziationen mit
+func preloading(associations ...string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, association := range associations {
+			db = db.Preload(association)
+		}
+		return db
 	}
+}
 
-	return movies, total, nil
+// rankedBy ordnet Volltexttreffer nach ts_rank, bei gleichem Rang nach Sortiertitel
+func rankedBy(config, query string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "ts_rank(search_vector, websearch_to_tsquery(?::regconfig, ?)) DESC, sort_key, id",
+			Vars:               []interface{}{config, query},
+			WithoutParentheses: true,
+		}})
+	}
 }
 
-func (r *MovieRepository) GetByID(id uint) (models.Movie, error) {
+// GetByID lädt einen Film einschließlich seiner Exemplare, Lagerorte, einer laufenden Ausleihe, Genres und Credits
+func (r *MovieRepository) GetByID(ownerID, id uint) (models.Movie, error) {
 	var movie models.Movie
-	result := r.db.First(&movie, id)
+	result := r.db.Scopes(ownedBy(ownerID)).Preload("Location").Preload("ActiveLoan", activeLoan).Preload("Copies", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Preload("Copies.Location").Preload("Genres", func(db *gorm.DB) *gorm.DB {
+		return db.Order("name")
+	}).Preload("Credits", func(db *gorm.DB) *gorm.DB {
+		return db.Order("kind, credit_order, id")
+	}).Preload("Credits.Person").First(&movie, id)
 	return movie, result.Error
 }
 
@@ -90,19 +354,135 @@ func (r *MovieRepository) Update(movie *models.Movie) error {
 	return r.db.Save(movie).Error
 }
 
-func (r *MovieRepository) Delete(id uint) error {
-	result := r.db.Delete(&models.Movie{}, id)
+// UpdateFields aktualisiert einzelne Spalten eines Films, ohne Assoziationen zu speichern
+func (r *MovieRepository) UpdateFields(id uint, fields map[string]interface{}) error {
+	return r.db.Model(&models.Movie{}).Where("id = ?", id).Updates(fields).Error
+}
+
+// Delete löscht einen Film zusammen mit seinen Exemplaren, Ausleihen, Credits und Genre-Zuordnungen
+func (r *MovieRepository) Delete(ownerID, id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Scopes(ownedBy(ownerID)).Delete(&models.Movie{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Where("movie_id = ?", id).Delete(&models.Loan{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("movie_id = ?", id).Delete(&models.Credit{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM movie_genres WHERE movie_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Where("movie_id = ?", id).Delete(&models.Copy{}).Error
+	})
+}
+
+// GetByLocation liefert die Filme, die selbst oder mit mindestens einem Exemplar an einem Lagerort stehen
+func (r *MovieRepository) GetByLocation(ownerID, locationID uint, offset, limit int) ([]models.Movie, int64, error) {
+	var movies []models.Movie
+	var total int64
+
+	locationQuery := r.db.Model(&models.Movie{}).Scopes(ownedBy(ownerID)).Where(
+		"location_id = ? OR id IN (?)",
+		locationID, r.db.Model(&models.Copy{}).Select("movie_id").Where("location_id = ?", locationID),
+	)
+
+	if err := locationQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := locationQuery.Preload("Location").Preload("Copies", "location_id = ?", locationID).Order("sort_key, id").Offset(offset).Limit(limit).Find(&movies)
 	if result.Error != nil {
-		return result.Error
+		return nil, 0, result.Error
 	}
-	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
+
+	return movies, total, nil
+}
+
+// GetByPerson liefert die Filme, an denen eine Person mitgewirkt hat
+// kind und job schränken optional auf Besetzung/Stab bzw. eine Aufgabe wie "Director" ein.
+func (r *MovieRepository) GetByPerson(ownerID, personID uint, kind, job string, filter models.MovieFilter, offset, limit int) ([]models.Movie, int64, error) {
+	credits := r.db.Model(&models.Credit{}).Select("movie_id").Where("person_id = ?", personID)
+	if kind != "" {
+		credits = credits.Where("kind = ?", kind)
+	}
+	if job != "" {
+		credits = credits.Where("job = ?", job)
+	}
+	return r.paginate(r.db.Model(&models.Movie{}).Scopes(ownedBy(ownerID), matching(filter)).Where("id IN (?)", credits), filter, offset, limit)
+}
+
+// GetByGenre liefert die Filme eines Genres
+func (r *MovieRepository) GetByGenre(ownerID, genreID uint, filter models.MovieFilter, offset, limit int) ([]models.Movie, int64, error) {
+	genres := r.db.Table("movie_genres").Select("movie_id").Where("genre_id = ?", genreID)
+	return r.paginate(r.db.Model(&models.Movie{}).Scopes(ownedBy(ownerID), matching(filter)).Where("id IN (?)", genres), filter, offset, limit)
+}
+
+// paginate zählt die Treffer einer Abfrage und lädt eine sortierte Seite davon
+func (r *MovieRepository) paginate(query *gorm.DB, filter models.MovieFilter, offset, limit int) ([]models.Movie, int64, error) {
+	var movies []models.Movie
+	var total int64
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := query.Preload("Location").Preload("Genres", func(db *gorm.DB) *gorm.DB {
+		return db.Order("name")
+	}).Scopes(sorted(filter)).Offset(offset).Limit(limit).Find(&movies)
+	if result.Error != nil {
+		return nil, 0, result.Error
 	}
-	return nil
+
+	return movies, total, nil
 }
 
-func (r *MovieRepository) GetByTMDBID(tmdbID string) (models.Movie, error) {
+// FindDuplicate sucht einen Eintrag mit demselben Medientyp und derselben TMDB-ID
+// Bei Serien zählt auch der Staffelbereich, damit mehrere Staffel-Boxen einer Serie nebeneinander bestehen können.
+// Ein gespeicherter candidate kollidiert nicht mit sich selbst.
+func (r *MovieRepository) FindDuplicate(ownerID uint, candidate *models.Movie) (models.Movie, error) {
 	var movie models.Movie
-	result := r.db.Where("tmdb_id = ?", tmdbID).First(&movie)
+	query := r.db.Scopes(ownedBy(ownerID)).
+		Where("media_type = ? AND tmdb_id = ? AND season_from = ? AND season_to = ?", candidate.MediaType, candidate.TMDBId, candidate.SeasonFrom, candidate.SeasonTo)
+	if candidate.ID != 0 {
+		query = query.Where("id <> ?", candidate.ID)
+	}
+	result := query.First(&movie)
 	return movie, result.Error
 }
+
+// BackfillSortTitles leitet die Sortiertitel aller Filme ohne eigenen Sortiertitel neu ab
+// Nötig für Filme, die vor Einführung der Sortiertitel angelegt wurden, und nach einer Änderung der Artikelsprachen.
+func (r *MovieRepository) BackfillSortTitles() error {
+	var movies []models.Movie
+	if err := r.db.Select("id, title, sort_title, sort_title_custom, sort_key").
+		Where("sort_title_custom = ? OR sort_title_custom IS NULL OR sort_key = '' OR sort_key IS NULL", false).
+		Find(&movies).Error; err != nil {
+		return err
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, movie := range movies {
+			sortTitle := movie.SortTitle
+			if !movie.SortTitleCustom || sortTitle == "" {
+				sortTitle = models.DeriveSortTitle(movie.Title)
+			}
+			sortKey := models.FoldText(sortTitle)
+			if sortTitle == movie.SortTitle && sortKey == movie.SortKey {
+				continue
+			}
+			if err := tx.Model(&models.Movie{}).Where("id = ?", movie.ID).UpdateColumns(map[string]interface{}{
+				"sort_title":        sortTitle,
+				"sort_title_custom": sortTitle != models.DeriveSortTitle(movie.Title),
+				"sort_key":          sortKey,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RevocationRepository speichert den Widerrufsstatus von Refresh-Tokens in der Datenbank
+// und implementiert services.RevocationStore für Installationen ohne Redis
+type RevocationRepository struct {
+	db *gorm.DB
+}
+
+func NewRevocationRepository(db *gorm.DB) *RevocationRepository {
+	return &RevocationRepository{db: db}
+}
+
+// Add legt key nur an, wenn er noch nicht existiert oder bereits abgelaufen ist
+func (r *RevocationRepository) Add(key string, value int64, ttl time.Duration) (bool, error) {
+	if err := r.db.Where("key = ? AND expires_at < ?", key, time.Now()).Delete(&models.RevocationEntry{}).Error; err != nil {
+		return false, err
+	}
+
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.RevocationEntry{
+		Key:       key,
+		Value:     value,
+		ExpiresAt: expiresAt(ttl),
+	})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *RevocationRepository) Set(key string, value int64, ttl time.Duration) error {
+	return r.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&models.RevocationEntry{
+		Key:       key,
+		Value:     value,
+		ExpiresAt: expiresAt(ttl),
+	}).Error
+}
+
+// Increment erhöht den Wert in einer einzigen Anweisung (INSERT ... ON CONFLICT DO UPDATE SET value = value + 1)
+func (r *RevocationRepository) Increment(key string) (int64, error) {
+	entry := models.RevocationEntry{Key: key, Value: 1}
+	err := r.db.Clauses(
+		clause.OnConflict{
+			Columns:   []clause.Column{{Name: "key"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"value": gorm.Expr("revocation_entries.value + 1"), "expires_at": nil}),
+		},
+		clause.Returning{Columns: []clause.Column{{Name: "value"}}},
+	).Create(&entry).Error
+	return entry.Value, err
+}
+
+func (r *RevocationRepository) Get(key string) (int64, bool, error) {
+	var entry models.RevocationEntry
+	result := r.db.Where("key = ? AND (expires_at IS NULL OR expires_at >= ?)", key, time.Now()).Limit(1).Find(&entry)
+	if result.Error != nil {
+		return 0, false, result.Error
+	}
+	return entry.Value, result.RowsAffected == 1, nil
+}
+
+// DeleteExpired entfernt abgelaufene Einträge
+func (r *RevocationRepository) DeleteExpired() error {
+	return r.db.Where("expires_at < ?", time.Now()).Delete(&models.RevocationEntry{}).Error
+}
+
+func expiresAt(ttl time.Duration) *time.Time {
+	if ttl <= 0 {
+		return nil
+	}
+	at := time.Now().Add(ttl)
+	return &at
+}
@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"strings"
+
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"gorm.io/gorm"
+)
+
+// Spalten der Zahlenfelder der Suchsprache
+var queryColumns = map[string]string{
+	models.QueryFieldYear:    "year",
+	models.QueryFieldRating:  "rating",
+	models.QueryFieldRuntime: "runtime",
+	models.QueryFieldDiscs:   "disc_count",
+}
+
+// querying wendet die Bedingungen eines geparsten Suchbegriffs als parametrisierte SQL-Bedingungen an
+// Der positive Freitext wird von der eigentlichen Suche behandelt und hier ausgelassen.
+func querying(db *gorm.DB, query models.SearchQuery) func(*gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		for _, term := range query.Conditions() {
+			sql, args := termCondition(db, term)
+			if term.Negate {
+				sql = "NOT (" + sql + ")"
+			}
+			tx = tx.Where(sql, args...)
+		}
+		return tx
+	}
+}
+
+// termCondition übersetzt eine Bedingung in SQL mit Platzhaltern; Werte landen nie im SQL-Text
+func termCondition(db *gorm.DB, term models.QueryTerm) (string, []interface{}) {
+	like := "%" + strings.ToLower(term.Text) + "%"
+
+	switch term.Field {
+	case models.QueryFieldYear, models.QueryFieldRating, models.QueryFieldRuntime, models.QueryFieldDiscs:
+		return numericCondition(queryColumns[term.Field], term)
+	case models.QueryFieldFormat, models.QueryFieldRegion, models.QueryFieldStatus:
+		return term.Field + " = ?", []interface{}{term.Text}
+	case models.QueryFieldEdition:
+		return "LOWER(COALESCE(edition, '')) LIKE ?", []interface{}{like}
+	case models.QueryFieldTitle:
+		return "search_title LIKE ?", []interface{}{"%" + models.FoldText(term.Text) + "%"}
+	case models.QueryFieldGenre:
+		return "id IN (?)", []interface{}{genreMovies(db, like)}
+	case models.QueryFieldDirector:
+		return "id IN (?)", []interface{}{creditMovies(db, like).Where("credits.job = ?", "Director")}
+	case models.QueryFieldActor:
+		return "id IN (?)", []interface{}{creditMovies(db, like).Where("credits.kind = ?", models.CreditCast)}
+	default:
+		// Freitext: Titel, Beschreibung, Inhaltsangabe und Genres, damit z. B. -horror auch das Genre ausschließt
+		return "search_title LIKE ? OR LOWER(COALESCE(description, '')) LIKE ? OR LOWER(COALESCE(overview, '')) LIKE ? OR id IN (?)",
+			[]interface{}{"%" + models.FoldText(term.Text) + "%", like, like, genreMovies(db, like)}
+	}
+}
+
+func numericCondition(column string, term models.QueryTerm) (string, []interface{}) {
+	switch term.Op {
+	case models.QueryOpEqual:
+		return column + " = ?", []interface{}{term.Min}
+	case models.QueryOpGreater:
+		return column + " > ?", []interface{}{term.Min}
+	case models.QueryOpGreaterEqual:
+		return column + " >= ?", []interface{}{term.Min}
+	case models.QueryOpLess:
+		return column + " < ?", []interface{}{term.Max}
+	case models.QueryOpLessEqual:
+		return column + " <= ?", []interface{}{term.Max}
+	}
+
+	// Bereich, optional nach einer Seite offen
+	var conditions []string
+	var args []interface{}
+	if term.HasMin {
+		conditions = append(conditions, column+" >= ?")
+		args = append(args, term.Min)
+	}
+	if term.HasMax {
+		conditions = append(conditions, column+" <= ?")
+		args = append(args, term.Max)
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+func genreMovies(db *gorm.DB, like string) *gorm.DB {
+	return db.Table("movie_genres").Select("movie_genres.movie_id").
+		Joins("JOIN genres ON genres.id = movie_genres.genre_id").
+		Where("LOWER(genres.name) LIKE ?", like)
+}
+
+func creditMovies(db *gorm.DB, like string) *gorm.DB {
+	return db.Table("credits").Select("credits.movie_id").
+		Joins("JOIN people ON people.id = credits.person_id").
+		Where("LOWER(people.name) LIKE ?", like)
+}
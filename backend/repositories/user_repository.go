@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"gorm.io/gorm"
+)
+
+type UserRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+func (r *UserRepository) Create(user *models.User) error {
+	return r.db.Create(user).Error
+}
+
+// Register legt user an; ist er der erste Benutzer, erhält er firstRole und übernimmt alle Filme ohne Besitzer
+// Zählen, Anlegen und Übernehmen laufen in einer Transaktion. Unter PostgreSQL sperrt sie die Tabelle users gegen
+// gleichzeitige Registrierungen, SQLite lässt ohnehin nur eine schreibende Transaktion zu. So entsteht genau ein
+// erster Benutzer.
+func (r *UserRepository) Register(user *models.User, firstRole string) (bool, error) {
+	first := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if tx.Dialector.Name() == "postgres" {
+			if err := tx.Exec("LOCK TABLE users IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+				return err
+			}
+		}
+		var count int64
+		if err := tx.Model(&models.User{}).Count(&count).Error; err != nil {
+			return err
+		}
+		first = count == 0
+		if first {
+			user.Role = firstRole
+		}
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		if !first {
+			return nil
+		}
+		// Filme aus der Zeit vor den Benutzerkonten gehören dem ersten Benutzer
+		return tx.Model(&models.Movie{}).Where("owner_id IS NULL").Update("owner_id", user.ID).Error
+	})
+	return first, err
+}
+
+func (r *UserRepository) GetByID(id uint) (models.User, error) {
+	var user models.User
+	result := r.db.First(&user, id)
+	return user, result.Error
+}
+
+func (r *UserRepository) GetByUsername(username string) (models.User, error) {
+	var user models.User
+	result := r.db.Where("username = ?", username).First(&user)
+	return user, result.Error
+}
+
+func (r *UserRepository) Count() (int64, error) {
+	var count int64
+	err := r.db.Model(&models.User{}).Count(&count).Error
+	return count, err
+}
+
+func (r *UserRepository) List() ([]models.User, error) {
+	var users []models.User
+	result := r.db.Order("id").Find(&users)
+	return users, result.Error
+}
+
+func (r *UserRepository) Update(user *models.User) error {
+	return r.db.Save(user).Error
+}
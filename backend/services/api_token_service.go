@@ -0,0 +1,93 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/repositories"
+)
+
+var (
+	ErrAPITokenNotFound = errors.New("API-Token nicht gefunden")
+	ErrInvalidAPIToken  = errors.New("Ungültiger API-Token")
+)
+
+// APITokenService verwaltet persönliche API-Tokens und implementiert auth.UserResolver
+type APITokenService struct {
+	repo     *repositories.APITokenRepository
+	userRepo *repositories.UserRepository
+}
+
+func NewAPITokenService(repo *repositories.APITokenRepository, userRepo *repositories.UserRepository) *APITokenService {
+	return &APITokenService{repo: repo, userRepo: userRepo}
+}
+
+// CreateToken legt einen neuen API-Token an
+// Der Klartext-Token wird nur hier zurückgegeben, gespeichert wird ausschließlich sein Hash
+func (s *APITokenService) CreateToken(userID uint, name, scope string) (models.CreatedAPIToken, error) {
+	plain, err := auth.GenerateAPIToken()
+	if err != nil {
+		return models.CreatedAPIToken{}, err
+	}
+
+	token := models.APIToken{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    plain[:len(auth.APITokenPrefix)+8],
+		TokenHash: auth.HashAPIToken(plain),
+		Scope:     scope,
+	}
+	if err := s.repo.Create(&token); err != nil {
+		return models.CreatedAPIToken{}, err
+	}
+
+	return models.CreatedAPIToken{APIToken: token, Token: plain}, nil
+}
+
+func (s *APITokenService) ListTokens(userID uint) ([]models.APIToken, error) {
+	return s.repo.ListByUser(userID)
+}
+
+func (s *APITokenService) RevokeToken(userID, id uint) error {
+	if err := s.repo.Delete(userID, id); err != nil {
+		return ErrAPITokenNotFound
+	}
+	return nil
+}
+
+// ResolveAPIToken sucht den Token anhand seines Hashes und liefert die Claims seines Benutzers
+// Die Rolle wird bei jeder Anfrage aus dem Benutzerkonto gelesen
+func (s *APITokenService) ResolveAPIToken(plain string) (*auth.Claims, error) {
+	token, err := s.repo.GetByHash(auth.HashAPIToken(plain))
+	if err != nil {
+		return nil, ErrInvalidAPIToken
+	}
+
+	user, err := s.userRepo.GetByID(token.UserID)
+	if err != nil {
+		return nil, ErrInvalidAPIToken
+	}
+
+	if err := s.repo.TouchLastUsed(token.ID, time.Now()); err != nil {
+		log.Printf("Fehler beim Aktualisieren von last_used_at für API-Token %d: %v", token.ID, err)
+	}
+
+	return &auth.Claims{
+		UserID:   user.ID,
+		Username: user.Username,
+		Role:     user.Role,
+		Scope:    token.Scope,
+	}, nil
+}
+
+// CurrentRole liest die Rolle eines Benutzers aus seinem Konto
+func (s *APITokenService) CurrentRole(userID uint) (string, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return "", ErrUserNotFound
+	}
+	return user.Role, nil
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/repositories"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrUsernameTaken       = errors.New("Benutzername ist bereits vergeben")
+	ErrInvalidCredentials  = errors.New("Ungültiger Benutzername oder Passwort")
+	ErrInvalidRefreshToken = errors.New("Ungültiger oder abgelaufener Refresh-Token")
+	ErrUserNotFound        = errors.New("Benutzer nicht gefunden")
+	ErrRefreshTokenReused  = errors.New("Refresh-Token wurde bereits verwendet; die Sitzung wurde beendet")
+)
+
+// TokenPair enthält einen Access-Token und den zugehörigen Refresh-Token
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+type AuthService struct {
+	repo        *repositories.UserRepository
+	jwt         *auth.JWTConfig
+	revocations RevocationStore
+}
+
+func NewAuthService(repo *repositories.UserRepository, jwtConfig *auth.JWTConfig, revocations RevocationStore) *AuthService {
+	return &AuthService{repo: repo, jwt: jwtConfig, revocations: revocations}
+}
+
+// Register legt einen neuen Benutzer an und speichert nur den bcrypt-Hash des Passworts
+// Der erste Benutzer wird Administrator und übernimmt alle Filme, die noch keinem Besitzer zugeordnet sind.
+// Alle weiteren Benutzer starten als Bearbeiter, damit sie ihre eigene Sammlung pflegen können;
+// ein Administrator kann sie bei Bedarf auf Betrachter herabstufen.
+func (s *AuthService) Register(username, password string) (models.User, error) {
+	if _, err := s.repo.GetByUsername(username); err == nil {
+		return models.User{}, ErrUsernameTaken
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	user := models.User{
+		Username:     username,
+		PasswordHash: string(hash),
+		Role:         auth.RoleEditor,
+	}
+	// Ob der Benutzer der erste ist, entscheidet das Repository in derselben Transaktion, in der es ihn anlegt
+	if _, err := s.repo.Register(&user, auth.RoleAdmin); err != nil {
+		// Eine gleichzeitige Registrierung kann den Namen zwischen Prüfung und Anlegen belegt haben;
+		// der eindeutige Index weist dann diese zweite ab
+		if _, lookupErr := s.repo.GetByUsername(username); lookupErr == nil {
+			return models.User{}, ErrUsernameTaken
+		}
+		return models.User{}, err
+	}
+
+	return user, nil
+}
+
+// Login prüft die Anmeldedaten und stellt bei Erfolg ein neues Token-Paar aus
+func (s *AuthService) Login(username, password string) (models.User, TokenPair, error) {
+	user, err := s.repo.GetByUsername(username)
+	if err != nil {
+		return models.User{}, TokenPair{}, ErrInvalidCredentials
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return models.User{}, TokenPair{}, ErrInvalidCredentials
+	}
+
+	tokens, err := s.startSession(user)
+	if err != nil {
+		return models.User{}, TokenPair{}, err
+	}
+	return user, tokens, nil
+}
+
+// Refresh rotiert einen Refresh-Token: der vorgelegte Token wird verbraucht und ein neues Token-Paar
+// derselben Token-Familie ausgestellt. Wird ein bereits verbrauchter Token erneut vorgelegt, gilt die
+// Familie als kompromittiert und wird vollständig widerrufen.
+func (s *AuthService) Refresh(refreshToken string) (models.User, TokenPair, error) {
+	claims, err := s.jwt.ParseRefreshToken(refreshToken)
+	if err != nil {
+		return models.User{}, TokenPair{}, ErrInvalidRefreshToken
+	}
+
+	user, err := s.repo.GetByID(claims.UserID)
+	if err != nil {
+		return models.User{}, TokenPair{}, ErrInvalidRefreshToken
+	}
+
+	generation, err := s.generation(user.ID)
+	if err != nil {
+		return models.User{}, TokenPair{}, err
+	}
+	if claims.Generation != generation {
+		return models.User{}, TokenPair{}, ErrInvalidRefreshToken
+	}
+
+	if _, revoked, err := s.revocations.Get(familyKey(claims.Family)); err != nil {
+		return models.User{}, TokenPair{}, err
+	} else if revoked {
+		return models.User{}, TokenPair{}, ErrInvalidRefreshToken
+	}
+
+	firstUse, err := s.revocations.Add(usedTokenKey(claims.ID), time.Now().Unix(), s.jwt.RefreshExpiration)
+	if err != nil {
+		return models.User{}, TokenPair{}, err
+	}
+	if !firstUse {
+		log.Printf("Wiederverwendeter Refresh-Token für Benutzer %d erkannt, widerrufe Token-Familie %s", user.ID, claims.Family)
+		if err := s.revokeFamily(claims.Family); err != nil {
+			return models.User{}, TokenPair{}, err
+		}
+		return models.User{}, TokenPair{}, ErrRefreshTokenReused
+	}
+
+	tokens, err := s.issueTokens(user, claims.Family, generation)
+	if err != nil {
+		return models.User{}, TokenPair{}, err
+	}
+	return user, tokens, nil
+}
+
+// Logout widerruft die Token-Familie des übergebenen Refresh-Tokens
+// Ungültige oder abgelaufene Tokens werden ignoriert, da sie ohnehin nicht mehr verwendet werden können.
+func (s *AuthService) Logout(refreshToken string) error {
+	claims, err := s.jwt.ParseRefreshToken(refreshToken)
+	if err != nil {
+		return nil
+	}
+	return s.revokeFamily(claims.Family)
+}
+
+// LogoutAll macht alle bisher ausgestellten Refresh-Tokens eines Benutzers ungültig
+// Bereits ausgestellte Access-Tokens bleiben bis zu ihrem Ablauf gültig. Die Generation wird atomar erhöht,
+// damit gleichzeitige Aufrufe keine Erhöhung verlieren.
+func (s *AuthService) LogoutAll(userID uint) error {
+	_, err := s.revocations.Increment(generationKey(userID))
+	return err
+}
+
+// ListUsers liefert alle Benutzerkonten
+func (s *AuthService) ListUsers() ([]models.User, error) {
+	return s.repo.List()
+}
+
+// UpdateRole ändert die Rolle eines Benutzers; sie gilt ab dem nächsten ausgestellten Access-Token
+func (s *AuthService) UpdateRole(userID uint, role string) (models.User, error) {
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		return models.User{}, ErrUserNotFound
+	}
+
+	user.Role = role
+	if err := s.repo.Update(&user); err != nil {
+		return models.User{}, err
+	}
+	return user, nil
+}
+
+func (s *AuthService) issueTokens(user models.User, family string, generation int64) (TokenPair, error) {
+	accessToken, err := s.jwt.GenerateAccessToken(user.ID, user.Username, user.Role)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	refreshToken, err := s.jwt.GenerateFamilyRefreshToken(user.ID, family, generation)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	return TokenPair{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+// startSession stellt das erste Token-Paar einer neuen Token-Familie aus
+func (s *AuthService) startSession(user models.User) (TokenPair, error) {
+	family, err := auth.NewTokenID()
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	generation, err := s.generation(user.ID)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	return s.issueTokens(user, family, generation)
+}
+
+func (s *AuthService) generation(userID uint) (int64, error) {
+	generation, _, err := s.revocations.Get(generationKey(userID))
+	return generation, err
+}
+
+// revokeFamily sperrt eine Token-Familie so lange, wie ihr jüngster Refresh-Token gültig sein kann
+func (s *AuthService) revokeFamily(family string) error {
+	return s.revocations.Set(familyKey(family), time.Now().Unix(), s.jwt.RefreshExpiration)
+}
+
+func usedTokenKey(id string) string {
+	return "refresh:used:" + id
+}
+
+func familyKey(family string) string {
+	return "refresh:family:" + family
+}
+
+func generationKey(userID uint) string {
+	return fmt.Sprintf("refresh:generation:%d", userID)
+}
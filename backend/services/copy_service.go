@@ -0,0 +1,93 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/repositories"
+)
+
+var ErrCopyNotFound = errors.New("Exemplar nicht gefunden")
+
+// CopyService verwaltet die Exemplare eines Films
+// Der Zugriff ist über den Film auf dessen Besitzer beschränkt.
+type CopyService struct {
+	repo         *repositories.CopyRepository
+	movieRepo    *repositories.MovieRepository
+	locationRepo *repositories.LocationRepository
+}
+
+func NewCopyService(repo *repositories.CopyRepository, movieRepo *repositories.MovieRepository, locationRepo *repositories.LocationRepository) *CopyService {
+	return &CopyService{repo: repo, movieRepo: movieRepo, locationRepo: locationRepo}
+}
+
+func (s *CopyService) ListCopies(ownerID, movieID uint) ([]models.Copy, error) {
+	if _, err := s.movieRepo.GetByID(ownerID, movieID); err != nil {
+		return nil, ErrMovieNotFound
+	}
+	return s.repo.ListByMovie(movieID)
+}
+
+func (s *CopyService) GetCopy(ownerID, movieID, id uint) (models.Copy, error) {
+	if _, err := s.movieRepo.GetByID(ownerID, movieID); err != nil {
+		return models.Copy{}, ErrMovieNotFound
+	}
+	item, err := s.repo.GetByID(movieID, id)
+	if err != nil {
+		return models.Copy{}, ErrCopyNotFound
+	}
+	return item, nil
+}
+
+func (s *CopyService) CreateCopy(ownerID, movieID uint, item *models.Copy) error {
+	if _, err := s.movieRepo.GetByID(ownerID, movieID); err != nil {
+		return ErrMovieNotFound
+	}
+	if err := checkLocation(s.locationRepo, ownerID, item.LocationID); err != nil {
+		return err
+	}
+	item.ID = 0
+	item.MovieID = movieID
+	item.Location = nil
+	if err := s.repo.Create(item); err != nil {
+		return err
+	}
+	return s.reload(item)
+}
+
+func (s *CopyService) UpdateCopy(ownerID, movieID uint, item *models.Copy) error {
+	existing, err := s.GetCopy(ownerID, movieID, item.ID)
+	if err != nil {
+		return err
+	}
+	if err := checkLocation(s.locationRepo, ownerID, item.LocationID); err != nil {
+		return err
+	}
+	item.MovieID = movieID
+	item.CreatedAt = existing.CreatedAt
+	item.Location = nil
+	if err := s.repo.Update(item); err != nil {
+		return err
+	}
+	return s.reload(item)
+}
+
+func (s *CopyService) DeleteCopy(ownerID, movieID, id uint) error {
+	if _, err := s.movieRepo.GetByID(ownerID, movieID); err != nil {
+		return ErrMovieNotFound
+	}
+	if err := s.repo.Delete(movieID, id); err != nil {
+		return ErrCopyNotFound
+	}
+	return nil
+}
+
+// reload lädt ein gespeichertes Exemplar erneut, damit die Antwort den Lagerort enthält
+func (s *CopyService) reload(item *models.Copy) error {
+	stored, err := s.repo.GetByID(item.MovieID, item.ID)
+	if err != nil {
+		return err
+	}
+	*item = stored
+	return nil
+}
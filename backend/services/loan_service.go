@@ -0,0 +1,111 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/repositories"
+)
+
+var (
+	ErrAlreadyLent      = errors.New("Der Film ist bereits verliehen")
+	ErrNotLent          = errors.New("Der Film ist nicht verliehen")
+	ErrNotInCollection  = errors.New("Nur Filme im Bestand können verliehen werden")
+	ErrInvalidLoanDates = errors.New("Fälligkeits- und Rückgabedatum dürfen nicht vor dem Ausleihdatum liegen")
+)
+
+// LoanService verwaltet das Verleihen und Zurückgeben von Filmen
+type LoanService struct {
+	repo      *repositories.LoanRepository
+	movieRepo *repositories.MovieRepository
+}
+
+func NewLoanService(repo *repositories.LoanRepository, movieRepo *repositories.MovieRepository) *LoanService {
+	return &LoanService{repo: repo, movieRepo: movieRepo}
+}
+
+// Lend verleiht einen Film; ein Film kann nur einmal gleichzeitig verliehen sein
+func (s *LoanService) Lend(ownerID, movieID uint, request models.LoanRequest) (models.Loan, error) {
+	movie, err := s.movieRepo.GetByID(ownerID, movieID)
+	if err != nil {
+		return models.Loan{}, ErrMovieNotFound
+	}
+	if movie.Status != models.StatusOwned {
+		return models.Loan{}, ErrNotInCollection
+	}
+	if movie.ActiveLoan != nil {
+		return models.Loan{}, ErrAlreadyLent
+	}
+
+	loan := models.Loan{
+		MovieID:         movie.ID,
+		OwnerID:         movie.OwnerID,
+		BorrowerName:    request.BorrowerName,
+		BorrowerContact: request.BorrowerContact,
+		LentOn:          request.LentOn,
+		DueOn:           request.DueOn,
+	}
+	if loan.LentOn == "" {
+		loan.LentOn = s.today()
+	}
+	// ISO-Daten lassen sich als Zeichenketten vergleichen
+	if loan.DueOn != "" && loan.DueOn < loan.LentOn {
+		return models.Loan{}, ErrInvalidLoanDates
+	}
+
+	if err := s.repo.Create(&loan); err != nil {
+		// Eine gleichzeitige Ausleihe kann zwischen Prüfung und Anlegen entstanden sein;
+		// der Index für aktive Ausleihen weist dann diese zweite ab
+		if _, lookupErr := s.repo.GetActiveByMovie(movie.ID); lookupErr == nil {
+			return models.Loan{}, ErrAlreadyLent
+		}
+		return models.Loan{}, err
+	}
+	return loan, nil
+}
+
+// Return beendet die laufende Ausleihe eines Films
+func (s *LoanService) Return(ownerID, movieID uint, request models.ReturnRequest) (models.Loan, error) {
+	if _, err := s.movieRepo.GetByID(ownerID, movieID); err != nil {
+		return models.Loan{}, ErrMovieNotFound
+	}
+
+	loan, err := s.repo.GetActiveByMovie(movieID)
+	if err != nil {
+		return models.Loan{}, ErrNotLent
+	}
+
+	loan.ReturnedOn = request.ReturnedOn
+	if loan.ReturnedOn == "" {
+		loan.ReturnedOn = s.today()
+	}
+	if loan.ReturnedOn < loan.LentOn {
+		return models.Loan{}, ErrInvalidLoanDates
+	}
+
+	if err := s.repo.Update(&loan); err != nil {
+		return models.Loan{}, err
+	}
+	return loan, nil
+}
+
+// History liefert alle Ausleihen eines Films, neueste zuerst
+func (s *LoanService) History(ownerID, movieID uint) ([]models.Loan, error) {
+	if _, err := s.movieRepo.GetByID(ownerID, movieID); err != nil {
+		return nil, ErrMovieNotFound
+	}
+	return s.repo.ListByMovie(movieID)
+}
+
+func (s *LoanService) ListActive(ownerID uint) ([]models.Loan, error) {
+	return s.repo.ListActive(ownerID)
+}
+
+func (s *LoanService) ListOverdue(ownerID uint) ([]models.Loan, error) {
+	return s.repo.ListOverdue(ownerID, s.today())
+}
+
+func (s *LoanService) today() string {
+	return time.Now().Format(models.LoanDateLayout)
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/repositories"
+)
+
+var ErrLocationNotFound = errors.New("Lagerort nicht gefunden")
+
+// LocationService verwaltet die Lagerorte eines Benutzers
+type LocationService struct {
+	repo      *repositories.LocationRepository
+	movieRepo *repositories.MovieRepository
+}
+
+func NewLocationService(repo *repositories.LocationRepository, movieRepo *repositories.MovieRepository) *LocationService {
+	return &LocationService{repo: repo, movieRepo: movieRepo}
+}
+
+func (s *LocationService) ListLocations(ownerID uint) ([]models.Location, error) {
+	return s.repo.List(ownerID)
+}
+
+func (s *LocationService) GetLocation(ownerID, id uint) (models.Location, error) {
+	location, err := s.repo.GetByID(ownerID, id)
+	if err != nil {
+		return models.Location{}, ErrLocationNotFound
+	}
+	return location, nil
+}
+
+func (s *LocationService) CreateLocation(ownerID uint, location *models.Location) error {
+	location.ID = 0
+	location.OwnerID = nil
+	if ownerID != 0 {
+		location.OwnerID = &ownerID
+	}
+	return s.repo.Create(location)
+}
+
+func (s *LocationService) UpdateLocation(ownerID uint, location *models.Location) error {
+	existing, err := s.GetLocation(ownerID, location.ID)
+	if err != nil {
+		return err
+	}
+	location.OwnerID = existing.OwnerID
+	location.CreatedAt = existing.CreatedAt
+	return s.repo.Update(location)
+}
+
+func (s *LocationService) DeleteLocation(ownerID, id uint) error {
+	if err := s.repo.Delete(ownerID, id); err != nil {
+		return ErrLocationNotFound
+	}
+	return nil
+}
+
+// GetMoviesAtLocation liefert die Filme, die selbst oder mit einem Exemplar an einem Lagerort stehen
+func (s *LocationService) GetMoviesAtLocation(ownerID, id uint, offset, limit int) ([]models.Movie, int64, error) {
+	if _, err := s.GetLocation(ownerID, id); err != nil {
+		return nil, 0, err
+	}
+	return s.movieRepo.GetByLocation(ownerID, id, offset, limit)
+}
+
+// checkLocation stellt sicher, dass ein zugewiesener Lagerort zur Sammlung von ownerID gehört
+func checkLocation(repo *repositories.LocationRepository, ownerID uint, locationID *uint) error {
+	if locationID == nil {
+		return nil
+	}
+	if _, err := repo.GetByID(ownerID, *locationID); err != nil {
+		return ErrLocationNotFound
+	}
+	return nil
+}
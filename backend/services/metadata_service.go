@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/repositories"
+	"github.com/MichaelKlank/movie-collector/backend/tmdb"
+)
+
+// Höchstens so viele Darsteller werden pro Film gespeichert (nach TMDB-Reihenfolge)
+const maxCastMembers = 20
+
+// Aufgaben im Stab, die lokal gespeichert werden; alle übrigen Crew-Einträge werden verworfen
+var keyCrewJobs = map[string]bool{
+	"Director":                true,
+	"Screenplay":              true,
+	"Writer":                  true,
+	"Novel":                   true,
+	"Producer":                true,
+	"Original Music Composer": true,
+	"Director of Photography": true,
+	"Editor":                  true,
+}
+
+var (
+	ErrNotLinkedToTMDB = errors.New("Der Film ist nicht mit TMDB verknüpft")
+	ErrTMDBRequest     = errors.New("TMDB-Daten konnten nicht geladen werden")
+	ErrPersonNotFound  = errors.New("Person nicht gefunden")
+	ErrGenreNotFound   = errors.New("Genre nicht gefunden")
+)
+
+// MetadataService übernimmt Genres, Laufzeit und Credits aus TMDB in die lokale Datenbank
+type MetadataService struct {
+	client       *tmdb.Client
+	movieRepo    *repositories.MovieRepository
+	metadataRepo *repositories.MetadataRepository
+}
+
+func NewMetadataService(client *tmdb.Client, movieRepo *repositories.MovieRepository, metadataRepo *repositories.MetadataRepository) *MetadataService {
+	return &MetadataService{client: client, movieRepo: movieRepo, metadataRepo: metadataRepo}
+}
+
+// SyncMovie lädt die TMDB-Details eines Films und ersetzt dessen Genres, Laufzeit, Credits und Originaltitel
+// Bei Serien werden die Seriendetails geladen, die Laufzeit ist die einer Episode und die Anzahl der Episoden
+// wird für den Staffelbereich der Box übernommen. Fehler von TMDB werden als ErrTMDBRequest gemeldet und behalten ihre Fehlerart (z. B. tmdb.ErrNotFound).
+func (s *MetadataService) SyncMovie(ctx context.Context, ownerID, id uint) (models.Movie, error) {
+	movie, err := s.movieRepo.GetByID(ownerID, id)
+	if err != nil {
+		return models.Movie{}, ErrMovieNotFound
+	}
+	tmdbID, err := strconv.Atoi(movie.TMDBId)
+	if err != nil || tmdbID <= 0 {
+		return models.Movie{}, ErrNotLinkedToTMDB
+	}
+
+	var (
+		runtime int
+		genres  []tmdb.Genre
+		credits *tmdb.Credits
+		fields  = map[string]interface{}{}
+	)
+	if movie.MediaType == models.MediaTypeTV {
+		show, err := s.client.GetTVDetailsContext(ctx, tmdbID)
+		if err != nil {
+			return models.Movie{}, fmt.Errorf("%w: %w", ErrTMDBRequest, err)
+		}
+		runtime, genres, credits = show.EpisodeRuntime(), show.Genres, show.Credits
+		if show.OriginalName != "" {
+			fields["original_title"] = show.OriginalName
+		}
+		if episodes := show.EpisodeCount(movie.SeasonFrom, movie.SeasonTo); episodes > 0 {
+			fields["episode_count"] = episodes
+		}
+	} else {
+		details, err := s.client.GetMovieDetailsContext(ctx, tmdbID)
+		if err != nil {
+			return models.Movie{}, fmt.Errorf("%w: %w", ErrTMDBRequest, err)
+		}
+		runtime, genres, credits = details.Runtime, details.Genres, details.Credits
+		if details.OriginalTitle != "" {
+			fields["original_title"] = details.OriginalTitle
+		}
+	}
+
+	if err := s.metadataRepo.ReplaceMovieMetadata(movie.ID, runtime, genresFromTMDB(genres), creditsFromTMDB(credits)); err != nil {
+		return models.Movie{}, err
+	}
+	if len(fields) > 0 {
+		if err := s.movieRepo.UpdateFields(movie.ID, fields); err != nil {
+			return models.Movie{}, err
+		}
+	}
+
+	return s.movieRepo.GetByID(ownerID, movie.ID)
+}
+
+// GetPersonMovies liefert die Filme der Sammlung, an denen eine Person mitgewirkt hat
+func (s *MetadataService) GetPersonMovies(ownerID, personID uint, kind, job string, filter models.MovieFilter, offset, limit int) ([]models.Movie, int64, error) {
+	if _, err := s.metadataRepo.GetPerson(personID); err != nil {
+		return nil, 0, ErrPersonNotFound
+	}
+	return s.movieRepo.GetByPerson(ownerID, personID, kind, job, filter, offset, limit)
+}
+
+// ListGenres liefert die Genres der Sammlung mit der Anzahl ihrer Filme
+func (s *MetadataService) ListGenres(ownerID uint, filter models.MovieFilter) ([]models.GenreCount, error) {
+	return s.metadataRepo.ListGenres(ownerID, filter)
+}
+
+// GetGenreMovies liefert die Filme der Sammlung in einem Genre
+func (s *MetadataService) GetGenreMovies(ownerID, genreID uint, filter models.MovieFilter, offset, limit int) ([]models.Movie, int64, error) {
+	if _, err := s.metadataRepo.GetGenre(genreID); err != nil {
+		return nil, 0, ErrGenreNotFound
+	}
+	return s.movieRepo.GetByGenre(ownerID, genreID, filter, offset, limit)
+}
+
+func genresFromTMDB(tmdbGenres []tmdb.Genre) []models.Genre {
+	genres := make([]models.Genre, 0, len(tmdbGenres))
+	for _, genre := range tmdbGenres {
+		genres = append(genres, models.Genre{TMDBId: genre.ID, Name: genre.Name})
+	}
+	return genres
+}
+
+func creditsFromTMDB(tmdbCredits *tmdb.Credits) []models.Credit {
+	if tmdbCredits == nil {
+		return nil
+	}
+
+	var credits []models.Credit
+	for _, member := range tmdbCredits.Cast {
+		if len(credits) == maxCastMembers {
+			break
+		}
+		credits = append(credits, models.Credit{
+			Person:    &models.Person{TMDBId: member.ID, Name: member.Name, ProfilePath: member.ProfilePath},
+			Kind:      models.CreditCast,
+			Character: member.Character,
+			Order:     member.Order,
+		})
+	}
+	for i, member := range tmdbCredits.Crew {
+		if !keyCrewJobs[member.Job] {
+			continue
+		}
+		credits = append(credits, models.Credit{
+			Person:     &models.Person{TMDBId: member.ID, Name: member.Name, ProfilePath: member.ProfilePath},
+			Kind:       models.CreditCrew,
+			Job:        member.Job,
+			Department: member.Department,
+			Order:      i,
+		})
+	}
+	return credits
+}
@@ -1,74 +1,306 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"log"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/MichaelKlank/movie-collector/backend/models"
 	"github.com/MichaelKlank/movie-collector/backend/repositories"
 )
 
+var (
+	ErrMovieNotFound = errors.New("Film nicht gefunden")
+	ErrAlreadyOwned  = errors.New("Der Film gehört bereits zum Bestand")
+	// ErrDuplicateTMDBID meldet einen Film, dessen TMDB-ID samt Medientyp und Staffeln schon in der Sammlung ist
+	ErrDuplicateTMDBID = errors.New("Ein Film mit dieser TMDB-ID existiert bereits")
+	// ErrFuzzyWithoutText wird zurückgegeben, wenn die unscharfe Suche keinen Freitext enthält
+	ErrFuzzyWithoutText = errors.New("Die unscharfe Suche erfordert einen Suchbegriff")
+)
+
+// DefaultMetadataTimeout begrenzt, wie lange Anlegen und Verknüpfen eines Films auf TMDB warten
+const DefaultMetadataTimeout = 5 * time.Second
+
 type MovieService struct {
-	repo *repositories.MovieRepository
+	repo         *repositories.MovieRepository
+	locationRepo *repositories.LocationRepository
+	metadata     *MetadataService
+	// MetadataTimeout ist die Frist für die TMDB-Metadaten beim Anlegen und Verknüpfen einschließlich Wiederholungen
+	MetadataTimeout time.Duration
+}
+
+func NewMovieService(repo *repositories.MovieRepository, locationRepo *repositories.LocationRepository) *MovieService {
+	return &MovieService{repo: repo, locationRepo: locationRepo, MetadataTimeout: DefaultMetadataTimeout}
+}
+
+// UseMetadata lässt CreateMovie und UpdateMovie Genres, Laufzeit und Credits aus TMDB übernehmen,
+// sobald ein Film mit einer TMDB-ID angelegt oder verknüpft wird
+func (s *MovieService) UseMetadata(metadata *MetadataService) {
+	s.metadata = metadata
+}
+
+func (s *MovieService) GetAllMovies(ownerID uint) ([]models.Movie, error) {
+	return s.repo.GetAll(ownerID)
 }
 
-func NewMovieService(repo *repositories.MovieRepository) *MovieService {
-	return &MovieService{repo: repo}
+// GetMoviesPaginated ruft eine Seite der Filmliste ab
+// ownerID: Besitzer der Sammlung (0 = alle Filme)
+// filter: Optionale Filter
+// page: Offset und Limit oder ein Cursor; optional ohne Zählung
+// Gibt die Filmliste, die Gesamtanzahl (-1 ohne Zählung) mit den Nachbar-Cursorn und einen etwaigen Fehler zurück
+func (s *MovieService) GetMoviesPaginated(ownerID uint, filter models.MovieFilter, page models.PageRequest) ([]models.Movie, models.PageInfo, error) {
+	return s.repo.GetPaginated(ownerID, filter, page)
 }
 
-func (s *MovieService) GetAllMovies() ([]models.Movie, error) {
-	return s.repo.GetAll()
+// GetAlphabetIndex liefert für jeden Anfangsbuchstaben der nach Titel sortierten Liste die Anzahl der Filme
+// und die Seite (bei limit Einträgen pro Seite) bzw. den Cursor, an dem der erste davon steht.
+// Maßgeblich ist der Sortiertitel ohne führenden Artikel; Ziffern und Zeichen werden unter "#" zusammengefasst.
+// Gibt die Einträge ("#" vor A-Z), die Anzahl aller Filme und einen etwaigen Fehler zurück
+func (s *MovieService) GetAlphabetIndex(ownerID uint, filter models.MovieFilter, limit int) ([]models.AlphabetEntry, int64, error) {
+	filter.Sort = models.SortTitle
+	movies, err := s.repo.GetTitles(ownerID, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries := []models.AlphabetEntry{}
+	positions := map[string]int{}
+	for i, movie := range movies {
+		letter := models.IndexLetter(movie.SortTitle)
+		position, found := positions[letter]
+		if !found {
+			entry := models.AlphabetEntry{Letter: letter, Page: i/limit + 1}
+			// Der Cursor zeigt hinter den vorigen Film, damit die Seite mit diesem Film beginnt
+			if i > 0 {
+				entry.Cursor = models.CursorAt(filter, movies[i-1], false).String()
+			}
+			position = len(entries)
+			positions[letter] = position
+			entries = append(entries, entry)
+		}
+		entries[position].Count++
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Letter < entries[j].Letter
+	})
+	return entries, int64(len(movies)), nil
 }
 
-// GetMoviesPaginated ruft eine paginierte Liste von Filmen ab
-// offset: Anzahl der zu überspringenden Einträge
-// limit: Maximale Anzahl der zurückzugebenden Einträge
-// Gibt die Filmliste, die Gesamtanzahl der Filme und einen etwaigen Fehler zurück
-func (s *MovieService) GetMoviesPaginated(offset, limit int) ([]models.Movie, int64, error) {
-	return s.repo.GetPaginated(offset, limit)
+// GetFacets zählt die gefilterten Filme pro Jahrzehnt und Bewertungsbereich
+func (s *MovieService) GetFacets(ownerID uint, filter models.MovieFilter) (models.MovieFacets, error) {
+	return s.repo.Facets(ownerID, filter)
 }
 
 // SearchMovies sucht Filme basierend auf dem übergebenen Suchbegriff
+// Der Suchbegriff darf die Suchsprache enthalten (z. B. year:1990..1999 rating:>7 -horror);
+// Syntaxfehler werden als *models.QuerySyntaxError zurückgegeben.
+// ownerID: Besitzer der Sammlung (0 = alle Filme)
 // query: Der Suchbegriff
-// offset: Anzahl der zu überspringenden Einträge
-// limit: Maximale Anzahl der zurückzugebenden Einträge
-// Gibt die gefundenen Filme, die Gesamtanzahl der Treffer und einen etwaigen Fehler zurück
-func (s *MovieService) SearchMovies(query string, offset, limit int) ([]models.Movie, int64, error) {
-	if query == "" {
-		return s.GetMoviesPaginated(offset, limit)
+// filter: Optionale Filter
+// page: Offset und Limit oder ein Cursor; optional ohne Zählung
+// Gibt die gefundenen Filme, die Gesamtanzahl der Treffer (-1 ohne Zählung) mit den Nachbar-Cursorn und einen etwaigen Fehler zurück
+func (s *MovieService) SearchMovies(ownerID uint, query string, filter models.MovieFilter, page models.PageRequest) ([]models.Movie, models.PageInfo, error) {
+	parsed, err := models.ParseSearchQuery(query)
+	if err != nil {
+		return nil, models.PageInfo{}, err
 	}
-	return s.repo.SearchMovies(query, offset, limit)
+	if len(parsed.Terms) == 0 {
+		return s.GetMoviesPaginated(ownerID, filter, page)
+	}
+	return s.repo.SearchMovies(ownerID, parsed, filter, page)
+}
+
+// FuzzySearchMovies sucht Titel tippfehlertolerant und liefert jeden Treffer mit seiner Ähnlichkeit
+// Feldbedingungen der Suchsprache schränken die Treffer zusätzlich ein.
+func (s *MovieService) FuzzySearchMovies(ownerID uint, query string, filter models.MovieFilter, offset, limit int) ([]models.ScoredMovie, int64, error) {
+	parsed, err := models.ParseSearchQuery(query)
+	if err != nil {
+		return nil, 0, err
+	}
+	if strings.TrimSpace(parsed.FreeText()) == "" {
+		return nil, 0, ErrFuzzyWithoutText
+	}
+	return s.repo.FuzzySearch(ownerID, parsed, filter, offset, limit)
 }
 
-func (s *MovieService) GetMovieByID(id uint) (models.Movie, error) {
-	return s.repo.GetByID(id)
+func (s *MovieService) GetMovieByID(ownerID, id uint) (models.Movie, error) {
+	return s.repo.GetByID(ownerID, id)
 }
 
-func (s *MovieService) GetMovieByTMDBID(tmdbID string) (models.Movie, error) {
-	return s.repo.GetByTMDBID(tmdbID)
+// FindDuplicate liefert den Eintrag, mit dem movie als Duplikat kollidiert
+func (s *MovieService) FindDuplicate(ownerID uint, movie *models.Movie) (models.Movie, error) {
+	return s.repo.FindDuplicate(ownerID, movie)
 }
 
-func (s *MovieService) CreateMovie(movie *models.Movie) error {
+// CreateMovie legt einen Film in der Sammlung von ownerID an
+// Die Prüfung auf doppelte TMDB-IDs erfolgt nur innerhalb dieser Sammlung. ctx begrenzt den Abruf der TMDB-Metadaten.
+func (s *MovieService) CreateMovie(ctx context.Context, ownerID uint, movie *models.Movie) error {
+	if movie.MediaType == "" {
+		movie.MediaType = models.MediaTypeMovie
+	}
+	if err := movie.ValidateMedia(); err != nil {
+		return err
+	}
+	if err := checkLocation(s.locationRepo, ownerID, movie.LocationID); err != nil {
+		return err
+	}
 	if movie.TMDBId != "" {
-		_, err := s.repo.GetByTMDBID(movie.TMDBId)
+		_, err := s.repo.FindDuplicate(ownerID, movie)
 		if err == nil {
-			return errors.New("Ein Film mit dieser TMDB-ID existiert bereits")
+			return ErrDuplicateTMDBID
 		}
 	}
-	return s.repo.Create(movie)
+	if movie.Status == "" {
+		movie.Status = models.StatusOwned
+	}
+	movie.Copies = nil
+	movie.ActiveLoan = nil
+	movie.Location = nil
+	movie.Genres = nil
+	movie.Credits = nil
+	movie.OwnerID = nil
+	if ownerID != 0 {
+		movie.OwnerID = &ownerID
+	}
+	movie.SortTitleCustom = movie.SortTitle != "" && movie.SortTitle != models.DeriveSortTitle(movie.Title)
+	if err := s.repo.Create(movie); err != nil {
+		return err
+	}
+	if err := s.reload(ownerID, movie); err != nil {
+		return err
+	}
+	if movie.TMDBId != "" {
+		s.syncMetadata(ctx, ownerID, movie)
+	}
+	return nil
 }
 
-func (s *MovieService) UpdateMovie(movie *models.Movie) error {
-	_, err := s.repo.GetByID(movie.ID)
+// UpdateMovie ändert einen Film; ctx begrenzt wie bei CreateMovie den Abruf der TMDB-Metadaten
+func (s *MovieService) UpdateMovie(ctx context.Context, ownerID uint, movie *models.Movie) error {
+	existing, err := s.repo.GetByID(ownerID, movie.ID)
 	if err != nil {
 		return errors.New("Movie not found")
 	}
-	return s.repo.Update(movie)
+	// Ohne Angabe bleiben Medientyp und Staffeln einer Serie erhalten
+	if movie.MediaType == "" {
+		movie.MediaType = existing.MediaType
+	}
+	if movie.MediaType == models.MediaTypeTV && movie.SeasonFrom == 0 && movie.SeasonTo == 0 {
+		movie.SeasonFrom, movie.SeasonTo = existing.SeasonFrom, existing.SeasonTo
+	}
+	if movie.MediaType == models.MediaTypeTV && movie.EpisodeCount == 0 {
+		movie.EpisodeCount = existing.EpisodeCount
+	}
+	if err := movie.ValidateMedia(); err != nil {
+		return err
+	}
+	// Eine geänderte Verknüpfung darf wie beim Anlegen keinen zweiten Eintrag desselben Titels erzeugen
+	relinked := movie.TMDBId != existing.TMDBId || movie.MediaType != existing.MediaType ||
+		movie.SeasonFrom != existing.SeasonFrom || movie.SeasonTo != existing.SeasonTo
+	if movie.TMDBId != "" && relinked {
+		if _, err := s.repo.FindDuplicate(ownerID, movie); err == nil {
+			return ErrDuplicateTMDBID
+		}
+	}
+	if err := checkLocation(s.locationRepo, ownerID, movie.LocationID); err != nil {
+		return err
+	}
+	movie.OwnerID = existing.OwnerID
+	if movie.Status == "" {
+		movie.Status = existing.Status
+	}
+	if movie.Runtime == 0 {
+		movie.Runtime = existing.Runtime
+	}
+	if movie.OriginalTitle == "" {
+		movie.OriginalTitle = existing.OriginalTitle
+	}
+	// Ein unverändert zurückgesendeter Sortiertitel bleibt, was er war; ein abgeleiteter folgt so dem neuen Titel.
+	// Ein leerer Sortiertitel kehrt zur Ableitung zurück.
+	if movie.SortTitle != "" && movie.SortTitle == existing.SortTitle {
+		movie.SortTitleCustom = existing.SortTitleCustom
+	} else {
+		movie.SortTitleCustom = movie.SortTitle != "" && movie.SortTitle != models.DeriveSortTitle(movie.Title)
+	}
+	// Exemplare, Ausleihen und TMDB-Metadaten werden ausschließlich über ihre eigenen Endpunkte verwaltet
+	movie.Copies = nil
+	movie.ActiveLoan = nil
+	movie.Location = nil
+	movie.Genres = nil
+	movie.Credits = nil
+	if err := s.repo.Update(movie); err != nil {
+		return err
+	}
+	if err := s.reload(ownerID, movie); err != nil {
+		return err
+	}
+	if movie.TMDBId != "" && (movie.TMDBId != existing.TMDBId || movie.MediaType != existing.MediaType) {
+		s.syncMetadata(ctx, ownerID, movie)
+	}
+	return nil
+}
+
+// AcquireMovie übernimmt einen Film von der Wunschliste oder aus einer Vorbestellung in den Bestand
+// TMDB-Daten bleiben erhalten; ohne eigenes Format wird das Wunschformat übernommen.
+func (s *MovieService) AcquireMovie(ownerID, id uint) (models.Movie, error) {
+	movie, err := s.repo.GetByID(ownerID, id)
+	if err != nil {
+		return models.Movie{}, ErrMovieNotFound
+	}
+	if movie.Status == models.StatusOwned {
+		return models.Movie{}, ErrAlreadyOwned
+	}
+
+	fields := map[string]interface{}{
+		"status":       models.StatusOwned,
+		"target_price": 0,
+		"priority":     0,
+	}
+	if movie.Format == "" && movie.PreferredFormat != "" {
+		fields["format"] = movie.PreferredFormat
+	}
+	if err := s.repo.UpdateFields(movie.ID, fields); err != nil {
+		return models.Movie{}, err
+	}
+
+	return s.repo.GetByID(ownerID, movie.ID)
 }
 
-func (s *MovieService) DeleteMovie(id uint) error {
-	movie, err := s.repo.GetByID(id)
+func (s *MovieService) DeleteMovie(ownerID, id uint) error {
+	movie, err := s.repo.GetByID(ownerID, id)
 	if err != nil {
 		return errors.New("Movie not found")
 	}
-	return s.repo.Delete(movie.ID)
+	return s.repo.Delete(ownerID, movie.ID)
+}
+
+// syncMetadata übernimmt die TMDB-Metadaten eines neu verknüpften Films, sofern UseMetadata gesetzt ist
+// Der Abruf endet mit ctx, spätestens nach MetadataTimeout. Fehler werden nur protokolliert: der Film bleibt
+// gespeichert und lässt sich über POST /movies/{id}/metadata erneut abgleichen.
+func (s *MovieService) syncMetadata(ctx context.Context, ownerID uint, movie *models.Movie) {
+	if s.metadata == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.MetadataTimeout)
+	defer cancel()
+	synced, err := s.metadata.SyncMovie(ctx, ownerID, movie.ID)
+	if err != nil {
+		log.Printf("TMDB-Metadaten für Film %d (TMDB-ID %s) konnten nicht übernommen werden: %v", movie.ID, movie.TMDBId, err)
+		return
+	}
+	*movie = synced
+}
+
+// reload lädt einen gespeicherten Film erneut, damit die Antwort Lagerort und Exemplare enthält
+func (s *MovieService) reload(ownerID uint, movie *models.Movie) error {
+	stored, err := s.repo.GetByID(ownerID, movie.ID)
+	if err != nil {
+		return err
+	}
+	*movie = stored
+	return nil
 }
@@ -0,0 +1,17 @@
+package services
+
+import "time"
+
+// RevocationStore hält den serverseitigen Widerrufsstatus von Refresh-Tokens
+// Implementiert wird sie von cache.RevocationStore (Redis) und repositories.RevocationRepository (Datenbank).
+type RevocationStore interface {
+	// Add legt key nur an, wenn er noch nicht existiert; false bedeutet, dass key bereits vorhanden war
+	Add(key string, value int64, ttl time.Duration) (bool, error)
+	// Set legt key an oder überschreibt ihn; eine ttl von 0 bedeutet ohne Ablauf
+	Set(key string, value int64, ttl time.Duration) error
+	// Increment erhöht den Wert von key atomar um 1 und liefert den neuen Wert; ein fehlender key beginnt bei 0
+	// und läuft nicht ab
+	Increment(key string) (int64, error)
+	// Get liefert den Wert zu key; false bedeutet, dass key nicht existiert
+	Get(key string) (int64, bool, error)
+}
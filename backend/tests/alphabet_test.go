@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexLetter(t *testing.T) {
+	assert.Equal(t, "M", models.IndexLetter("Matrix"))
+	assert.Equal(t, "E", models.IndexLetter("Élite"))
+	assert.Equal(t, "#", models.IndexLetter("2001: Odyssee im Weltraum"))
+	assert.Equal(t, "#", models.IndexLetter("[REC]"))
+	assert.Equal(t, "#", models.IndexLetter(""))
+}
+
+func TestAlphabetIndex(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	router := testutil.SetupRouter(db)
+	_, token := testutil.CreateTestUser(t, db, "collector", auth.RoleEditor)
+
+	for _, title := range []string{"12 Monkeys", "Alien", "Aliens", "Blade Runner", "Die Hard", "Heat", "Matrix Reloaded", "The Matrix"} {
+		w := serveJSON(router, "POST", "/movies", token, models.Movie{Title: title, Year: 2000})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+	w := serveJSON(router, "POST", "/movies", token, models.Movie{Title: "Casablanca", Year: 1942, Status: models.StatusWishlist})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	t.Run("Index", func(t *testing.T) {
+		w := serveJSON(router, "GET", "/movies/index?limit=2", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response models.AlphabetIndexResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, int64(8), response.Meta.Total)
+		assert.Equal(t, 2, response.Meta.Limit)
+
+		letters := map[string]models.AlphabetEntry{}
+		order := []string{}
+		for _, entry := range response.Data {
+			letters[entry.Letter] = entry
+			order = append(order, entry.Letter)
+		}
+		assert.Equal(t, []string{"#", "A", "B", "H", "M"}, order)
+		assert.Equal(t, int64(2), letters["A"].Count)
+		assert.Equal(t, int64(2), letters["M"].Count)
+		assert.Equal(t, 1, letters["#"].Page)
+		assert.Empty(t, letters["#"].Cursor)
+		assert.Equal(t, 2, letters["B"].Page)
+
+		// Der Cursor eines Buchstabens führt auf eine Seite, die mit diesem Buchstaben beginnt
+		w = serveJSON(router, "GET", "/movies?limit=2&cursor="+letters["B"].Cursor, token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, []string{"Blade Runner", "Die Hard"}, movieTitles(t, w))
+	})
+
+	t.Run("Starts With", func(t *testing.T) {
+		for query, expected := range map[string][]string{
+			"m": {"The Matrix", "Matrix Reloaded"},
+			"H": {"Die Hard", "Heat"},
+			"#": {"12 Monkeys"},
+			"T": {},
+		} {
+			w := serveJSON(router, "GET", "/movies?starts_with="+query, token, nil)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			titles := movieTitles(t, w)
+			if len(expected) == 0 {
+				assert.Empty(t, titles, query)
+			} else {
+				assert.Equal(t, expected, titles, query)
+			}
+		}
+	})
+
+	t.Run("Invalid Parameters", func(t *testing.T) {
+		for _, path := range []string{"/movies?starts_with=%C3%84", "/movies?starts_with=ab", "/movies?starts_with=1", "/movies/index?sort=year"} {
+			w := serveJSON(router, "GET", path, token, nil)
+			assert.Equal(t, http.StatusBadRequest, w.Code, path)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createAPIToken(t *testing.T, router http.Handler, sessionToken, name, scope string) models.CreatedAPIToken {
+	w := serveJSON(router, "POST", "/auth/tokens", sessionToken, models.APITokenRequest{Name: name, Scope: scope})
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created models.CreatedAPIToken
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	return created
+}
+
+func TestAPITokens(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	router := testutil.SetupRouter(db)
+	_, sessionToken := testutil.CreateTestUser(t, db, "scripter", auth.RoleEditor)
+	_, otherToken := testutil.CreateTestUser(t, db, "other", auth.RoleEditor)
+
+	w := serveJSON(router, "POST", "/movies", otherToken, models.Movie{Title: "Not Mine", Year: 2001})
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	readToken := createAPIToken(t, router, sessionToken, "nightly export", auth.ScopeRead)
+	writeToken := createAPIToken(t, router, sessionToken, "home automation", auth.ScopeWrite)
+
+	t.Run("Only The Hash Is Stored", func(t *testing.T) {
+		assert.True(t, auth.IsAPIToken(readToken.Token))
+		assert.Equal(t, auth.ScopeRead, readToken.Scope)
+
+		var stored models.APIToken
+		require.NoError(t, db.First(&stored, readToken.ID).Error)
+		assert.Equal(t, auth.HashAPIToken(readToken.Token), stored.TokenHash)
+		assert.NotContains(t, stored.TokenHash, readToken.Token)
+	})
+
+	t.Run("List Does Not Expose Tokens", func(t *testing.T) {
+		w := serveJSON(router, "GET", "/auth/tokens", sessionToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), readToken.Token)
+		assert.NotContains(t, w.Body.String(), "token_hash")
+
+		var tokens []models.APIToken
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &tokens))
+		assert.Len(t, tokens, 2)
+	})
+
+	t.Run("Read Token", func(t *testing.T) {
+		w := serveJSON(router, "POST", "/movies", writeToken.Token, models.Movie{Title: "Scripted", Year: 2020})
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		w = serveJSON(router, "GET", "/movies", readToken.Token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"Scripted"}, movieTitles(t, w))
+
+		w = serveJSON(router, "POST", "/movies", readToken.Token, models.Movie{Title: "Denied", Year: 2020})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		var stored models.APIToken
+		require.NoError(t, db.First(&stored, readToken.ID).Error)
+		assert.NotNil(t, stored.LastUsedAt)
+	})
+
+	t.Run("API Tokens Cannot Manage Tokens", func(t *testing.T) {
+		w := serveJSON(router, "POST", "/auth/tokens", writeToken.Token, models.APITokenRequest{Name: "escalate", Scope: auth.ScopeWrite})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Invalid Scope", func(t *testing.T) {
+		w := serveJSON(router, "POST", "/auth/tokens", sessionToken, models.APITokenRequest{Name: "bad", Scope: "admin"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		path := "/auth/tokens/" + strconv.Itoa(int(writeToken.ID))
+
+		w := serveJSON(router, "DELETE", path, otherToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = serveJSON(router, "DELETE", path, sessionToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = serveJSON(router, "POST", "/movies", writeToken.Token, models.Movie{Title: "Too Late", Year: 2020})
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
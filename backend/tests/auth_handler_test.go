@@ -0,0 +1,189 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/repositories"
+	"github.com/MichaelKlank/movie-collector/backend/services"
+	"github.com/MichaelKlank/movie-collector/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func postCredentials(t *testing.T, router http.Handler, path, username, password string) *httptest.ResponseRecorder {
+	jsonData, err := json.Marshal(models.Credentials{Username: username, Password: password})
+	require.NoError(t, err)
+	req := httptest.NewRequest("POST", path, bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func findCookie(w *httptest.ResponseRecorder, name string) *http.Cookie {
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func TestAuthEndpoints(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	router := testutil.SetupRouter(db)
+
+	t.Run("Register", func(t *testing.T) {
+		w := postCredentials(t, router, "/auth/register", "alice", "secret-password")
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var user map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &user))
+		assert.Equal(t, "alice", user["username"])
+		assert.NotContains(t, user, "password_hash")
+		assert.NotContains(t, w.Body.String(), "secret-password")
+
+		var stored models.User
+		require.NoError(t, db.Where("username = ?", "alice").First(&stored).Error)
+		assert.NotEqual(t, "secret-password", stored.PasswordHash)
+	})
+
+	t.Run("Register Duplicate Username", func(t *testing.T) {
+		w := postCredentials(t, router, "/auth/register", "alice", "another-password")
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Register Race On Unique Index", func(t *testing.T) {
+		// Eine gleichzeitige Registrierung legt "carol" direkt nach der Prüfung auf freie Namen an
+		raced := false
+		require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:race_register", func(tx *gorm.DB) {
+			if _, ok := tx.Statement.Dest.(*models.User); ok && tx.RowsAffected == 0 && !raced {
+				raced = true
+				assert.NoError(t, db.Exec("INSERT INTO users (username, password_hash, role) VALUES (?, ?, ?)", "carol", "hash", auth.RoleViewer).Error)
+			}
+		}))
+		defer func() { _ = db.Callback().Query().Remove("test:race_register") }()
+
+		w := postCredentials(t, router, "/auth/register", "carol", "secret-password")
+		assert.True(t, raced)
+		assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	})
+
+	t.Run("Register Short Password", func(t *testing.T) {
+		w := postCredentials(t, router, "/auth/register", "bob", "short")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Login Wrong Password", func(t *testing.T) {
+		w := postCredentials(t, router, "/auth/login", "alice", "wrong-password")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Nil(t, findCookie(w, auth.AccessTokenCookie))
+	})
+
+	var refreshCookie *http.Cookie
+
+	t.Run("Login Sets Cookies", func(t *testing.T) {
+		w := postCredentials(t, router, "/auth/login", "alice", "secret-password")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		accessCookie := findCookie(w, auth.AccessTokenCookie)
+		refreshCookie = findCookie(w, auth.RefreshTokenCookie)
+		require.NotNil(t, accessCookie)
+		require.NotNil(t, refreshCookie)
+		assert.True(t, accessCookie.HttpOnly)
+		assert.Equal(t, http.SameSiteLaxMode, accessCookie.SameSite)
+		assert.NotEmpty(t, accessCookie.Value)
+		assert.NotEmpty(t, refreshCookie.Value)
+	})
+
+	t.Run("Refresh", func(t *testing.T) {
+		require.NotNil(t, refreshCookie)
+		req := httptest.NewRequest("POST", "/auth/refresh", nil)
+		req.AddCookie(refreshCookie)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotNil(t, findCookie(w, auth.AccessTokenCookie))
+		assert.NotNil(t, findCookie(w, auth.RefreshTokenCookie))
+	})
+
+	t.Run("Refresh Without Cookie", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/auth/refresh", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Refresh Rejects Access Token", func(t *testing.T) {
+		accessToken, err := testutil.TestJWTConfig().GenerateToken(1)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest("POST", "/auth/refresh", nil)
+		req.AddCookie(&http.Cookie{Name: auth.RefreshTokenCookie, Value: accessToken})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Logout Clears Cookies", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/auth/logout", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		cookie := findCookie(w, auth.AccessTokenCookie)
+		require.NotNil(t, cookie)
+		assert.Empty(t, cookie.Value)
+		assert.True(t, cookie.MaxAge < 0)
+	})
+}
+
+func TestRegisterConcurrentFirstUsers(t *testing.T) {
+	// Eine Datei statt :memory:, damit sich die Verbindungen des Pools dieselbe Datenbank teilen
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "users.db")+"?_busy_timeout=5000"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Movie{}))
+	require.NoError(t, db.Create(&models.Movie{Title: "Altbestand", Year: 1999}).Error)
+
+	service := services.NewAuthService(repositories.NewUserRepository(db), testutil.TestJWTConfig(), repositories.NewRevocationRepository(db))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// SQLite kann eine der gleichzeitigen Schreibtransaktionen als busy abweisen; das ist hier kein Fehler
+			_, _ = service.Register(fmt.Sprintf("user%d", i), "secret-password")
+		}(i)
+	}
+	wg.Wait()
+
+	var users []models.User
+	require.NoError(t, db.Order("id").Find(&users).Error)
+	require.NotEmpty(t, users)
+	admins := 0
+	for _, user := range users {
+		if user.Role == auth.RoleAdmin {
+			admins++
+		}
+	}
+	assert.Equal(t, 1, admins)
+	assert.Equal(t, auth.RoleAdmin, users[0].Role)
+
+	var movie models.Movie
+	require.NoError(t, db.First(&movie).Error)
+	require.NotNil(t, movie.OwnerID)
+	assert.Equal(t, users[0].ID, *movie.OwnerID)
+}
@@ -2,10 +2,12 @@ package tests
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -30,6 +32,8 @@ func TestGenerateAndValidateToken(t *testing.T) {
 }
 
 func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
 	// Setup
 	secret := "test-secret"
 	userID := uint(1)
@@ -40,41 +44,72 @@ func TestAuthMiddleware(t *testing.T) {
 	token, err := auth.GenerateToken(secret, userID, username, expiration)
 	assert.NoError(t, err)
 
-	// Test valid token
-	req := &http.Request{
-		Header: http.Header{
-			"Authorization": []string{"Bearer " + token},
-		},
+	newRouter := func(anonymousRead bool) *gin.Engine {
+		r := gin.New()
+		r.Use(auth.AuthMiddleware(secret, anonymousRead, nil))
+		handler := func(c *gin.Context) {
+			claims, ok := auth.GetClaims(c)
+			if !ok {
+				c.JSON(http.StatusOK, gin.H{"user_id": 0})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"user_id": claims.UserID, "username": claims.Username})
+		}
+		r.GET("/resource", handler)
+		r.POST("/resource", handler)
+		return r
 	}
-	claims, err := auth.AuthMiddleware(secret)(req)
-	assert.NoError(t, err)
-	assert.NotNil(t, claims)
-	assert.Equal(t, userID, claims.UserID)
-	assert.Equal(t, username, claims.Username)
 
-	// Test missing header
-	req = &http.Request{}
-	claims, err = auth.AuthMiddleware(secret)(req)
-	assert.Error(t, err)
-	assert.Nil(t, claims)
+	serve := func(r *gin.Engine, method string, header string, cookie *http.Cookie) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/resource", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	router := newRouter(true)
+
+	// Test valid token
+	w := serve(router, "POST", "Bearer "+token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"username":"testuser"`)
+
+	// Test valid token as cookie
+	w = serve(router, "POST", "", &http.Cookie{Name: auth.AccessTokenCookie, Value: token})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"user_id":1`)
+
+	// Test missing header on mutating request
+	w = serve(router, "POST", "", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), `"error"`)
+
+	// Test missing header on anonymous read
+	w = serve(router, "GET", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"user_id":0`)
+
+	// Test missing header on read with anonymous read disabled
+	w = serve(newRouter(false), "GET", "", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
 
 	// Test invalid header format
-	req = &http.Request{
-		Header: http.Header{
-			"Authorization": []string{"Invalid " + token},
-		},
-	}
-	claims, err = auth.AuthMiddleware(secret)(req)
-	assert.Error(t, err)
-	assert.Nil(t, claims)
+	w = serve(router, "GET", "Invalid "+token, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
 
 	// Test invalid token
-	req = &http.Request{
-		Header: http.Header{
-			"Authorization": []string{"Bearer invalid-token"},
-		},
-	}
-	claims, err = auth.AuthMiddleware(secret)(req)
-	assert.Error(t, err)
-	assert.Nil(t, claims)
+	w = serve(router, "POST", "Bearer invalid-token", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Test refresh token is not accepted as access token
+	refreshToken, err := (&auth.JWTConfig{Secret: []byte(secret), RefreshExpiration: time.Hour}).GenerateRefreshToken(userID)
+	assert.NoError(t, err)
+	w = serve(router, "POST", "Bearer "+refreshToken, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
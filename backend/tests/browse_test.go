@@ -0,0 +1,131 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/repositories"
+	"github.com/MichaelKlank/movie-collector/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrowseByPersonAndGenre(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	router := testutil.SetupRouter(db)
+	_, token := testutil.CreateTestUser(t, db, "keanu-fan", auth.RoleEditor)
+	_, otherToken := testutil.CreateTestUser(t, db, "brother", auth.RoleEditor)
+	metadataRepo := repositories.NewMetadataRepository(db)
+
+	createMovie := func(token string, movie models.Movie) models.Movie {
+		w := serveJSON(router, "POST", "/movies", token, movie)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var created models.Movie
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		return created
+	}
+	keanu := func(character string) models.Credit {
+		return models.Credit{Person: &models.Person{TMDBId: 6384, Name: "Keanu Reeves"}, Kind: models.CreditCast, Character: character}
+	}
+	wachowski := models.Credit{Person: &models.Person{TMDBId: 9339, Name: "Lana Wachowski"}, Kind: models.CreditCrew, Job: "Director"}
+	action := models.Genre{TMDBId: 28, Name: "Action"}
+	scifi := models.Genre{TMDBId: 878, Name: "Science Fiction"}
+
+	matrix := createMovie(token, models.Movie{Title: "The Matrix", Year: 1999})
+	wick := createMovie(token, models.Movie{Title: "John Wick", Year: 2014})
+	wishlist := createMovie(token, models.Movie{Title: "John Wick: Chapter 4", Year: 2023, Status: models.StatusWishlist})
+	foreign := createMovie(otherToken, models.Movie{Title: "Speed", Year: 1994})
+
+	require.NoError(t, metadataRepo.ReplaceMovieMetadata(matrix.ID, 136, []models.Genre{action, scifi}, []models.Credit{keanu("Neo"), wachowski}))
+	require.NoError(t, metadataRepo.ReplaceMovieMetadata(wick.ID, 101, []models.Genre{action}, []models.Credit{keanu("John Wick")}))
+	require.NoError(t, metadataRepo.ReplaceMovieMetadata(wishlist.ID, 169, []models.Genre{action}, []models.Credit{keanu("John Wick")}))
+	require.NoError(t, metadataRepo.ReplaceMovieMetadata(foreign.ID, 116, []models.Genre{action}, []models.Credit{keanu("Jack Traven")}))
+
+	var person models.Person
+	require.NoError(t, db.Where("tmdb_id = ?", 6384).First(&person).Error)
+	var director models.Person
+	require.NoError(t, db.Where("tmdb_id = ?", 9339).First(&director).Error)
+	var genre models.Genre
+	require.NoError(t, db.Where("tmdb_id = ?", 28).First(&genre).Error)
+
+	t.Run("Person Movies", func(t *testing.T) {
+		w := serveJSON(router, "GET", fmt.Sprintf("/people/%d/movies", person.ID), token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"John Wick", "The Matrix"}, movieTitles(t, w))
+
+		var response struct {
+			Meta models.PaginationMeta `json:"meta"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, int64(2), response.Meta.Total)
+
+		w = serveJSON(router, "GET", fmt.Sprintf("/people/%d/movies?status=all", person.ID), token, nil)
+		assert.Len(t, movieTitles(t, w), 3)
+
+		w = serveJSON(router, "GET", fmt.Sprintf("/people/%d/movies?job=Director", director.ID), token, nil)
+		assert.Equal(t, []string{"The Matrix"}, movieTitles(t, w))
+
+		w = serveJSON(router, "GET", fmt.Sprintf("/people/%d/movies?kind=cast", director.ID), token, nil)
+		assert.Empty(t, movieTitles(t, w))
+
+		w = serveJSON(router, "GET", fmt.Sprintf("/people/%d/movies?kind=extra", person.ID), token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = serveJSON(router, "GET", "/people/9999/movies", token, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Genres With Counts", func(t *testing.T) {
+		w := serveJSON(router, "GET", "/genres", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response models.GenreListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 2)
+		assert.Equal(t, 2, response.Meta.Total)
+		assert.Equal(t, "Action", response.Data[0].Name)
+		assert.Equal(t, int64(2), response.Data[0].MovieCount)
+		assert.Equal(t, "Science Fiction", response.Data[1].Name)
+		assert.Equal(t, int64(1), response.Data[1].MovieCount)
+
+		w = serveJSON(router, "GET", "/genres", otherToken, nil)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+		assert.Equal(t, int64(1), response.Data[0].MovieCount)
+	})
+
+	t.Run("Genre Movies", func(t *testing.T) {
+		w := serveJSON(router, "GET", fmt.Sprintf("/genres/%d/movies?limit=1", genre.ID), token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"John Wick"}, movieTitles(t, w))
+
+		w = serveJSON(router, "GET", fmt.Sprintf("/genres/%d/movies?limit=1&page=2", genre.ID), token, nil)
+		assert.Equal(t, []string{"The Matrix"}, movieTitles(t, w))
+
+		w = serveJSON(router, "GET", "/genres/9999/movies", token, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = serveJSON(router, "GET", "/genres/abc/movies", token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Anonymous Reads See No User Collection", func(t *testing.T) {
+		w := serveJSON(router, "GET", "/genres", "", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.GenreListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Empty(t, response.Data)
+
+		w = serveJSON(router, "GET", fmt.Sprintf("/genres/%d/movies", genre.ID), "", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, movieTitles(t, w))
+
+		w = serveJSON(router, "GET", fmt.Sprintf("/people/%d/movies", person.ID), "", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, movieTitles(t, w))
+	})
+}
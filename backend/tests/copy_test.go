@@ -0,0 +1,131 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMovieCopies(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	router := testutil.SetupRouter(db)
+	_, token := testutil.CreateTestUser(t, db, "collector", auth.RoleAdmin)
+	_, otherToken := testutil.CreateTestUser(t, db, "neighbour", auth.RoleAdmin)
+
+	w := serveJSON(router, "POST", "/movies", token, models.Movie{Title: "The Thing", Year: 1982, TMDBId: "1091"})
+	require.Equal(t, http.StatusCreated, w.Code)
+	var movie models.Movie
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &movie))
+	copiesPath := fmt.Sprintf("/movies/%d/copies", movie.ID)
+
+	var dvd models.Copy
+
+	t.Run("Create Copies", func(t *testing.T) {
+		w := serveJSON(router, "POST", copiesPath, token, models.Copy{
+			Format:       models.FormatDVD,
+			Barcode:      "5050582012345",
+			PurchaseDate: "2003-05-10",
+			Price:        12.99,
+			Condition:    models.ConditionGood,
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &dvd))
+		assert.Equal(t, movie.ID, dvd.MovieID)
+
+		w = serveJSON(router, "POST", copiesPath, token, models.Copy{Format: models.FormatBluray, Condition: models.ConditionNew})
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		// Der Film selbst bleibt pro TMDB-ID einmalig
+		w = serveJSON(router, "POST", "/movies", token, models.Movie{Title: "The Thing", Year: 1982, TMDBId: "1091"})
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Invalid Copy", func(t *testing.T) {
+		invalid := []models.Copy{
+			{},
+			{Format: "vhs"},
+			{Format: models.FormatDVD, PurchaseDate: "10.05.2003"},
+			{Format: models.FormatDVD, Price: -1},
+			{Format: models.FormatDVD, Condition: "scratched"},
+		}
+		for _, item := range invalid {
+			w := serveJSON(router, "POST", copiesPath, token, item)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		}
+	})
+
+	t.Run("List And Get", func(t *testing.T) {
+		w := serveJSON(router, "GET", copiesPath, token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var copies []models.Copy
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &copies))
+		require.Len(t, copies, 2)
+		assert.Equal(t, models.FormatDVD, copies[0].Format)
+		assert.Equal(t, models.FormatBluray, copies[1].Format)
+
+		w = serveJSON(router, "GET", fmt.Sprintf("/movies/%d", movie.ID), token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var detail models.Movie
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &detail))
+		assert.Len(t, detail.Copies, 2)
+
+		w = serveJSON(router, "GET", fmt.Sprintf("%s/%d", copiesPath, dvd.ID), token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "5050582012345")
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		dvd.Condition = models.ConditionFair
+		dvd.Price = 5
+		w := serveJSON(router, "PUT", fmt.Sprintf("%s/%d", copiesPath, dvd.ID), token, dvd)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var stored models.Copy
+		require.NoError(t, db.First(&stored, dvd.ID).Error)
+		assert.Equal(t, models.ConditionFair, stored.Condition)
+		assert.Equal(t, 5.0, stored.Price)
+	})
+
+	t.Run("Updating The Movie Keeps Copies", func(t *testing.T) {
+		w := serveJSON(router, "PUT", fmt.Sprintf("/movies/%d", movie.ID), token, models.Movie{Title: "The Thing", Year: 1982, TMDBId: "1091"})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var count int64
+		db.Model(&models.Copy{}).Where("movie_id = ?", movie.ID).Count(&count)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("Copies Of Foreign Movies Are Hidden", func(t *testing.T) {
+		w := serveJSON(router, "GET", copiesPath, otherToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = serveJSON(router, "POST", copiesPath, otherToken, models.Copy{Format: models.FormatDVD})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = serveJSON(router, "DELETE", fmt.Sprintf("%s/%d", copiesPath, dvd.ID), otherToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		path := fmt.Sprintf("%s/%d", copiesPath, dvd.ID)
+		w := serveJSON(router, "DELETE", path, token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = serveJSON(router, "GET", path, token, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = serveJSON(router, "DELETE", fmt.Sprintf("/movies/%d", movie.ID), token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var count int64
+		db.Model(&models.Copy{}).Where("movie_id = ?", movie.ID).Count(&count)
+		assert.Zero(t, count)
+	})
+}
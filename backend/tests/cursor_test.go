@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorPagination(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	router := testutil.SetupRouter(db)
+	_, token := testutil.CreateTestUser(t, db, "collector", auth.RoleEditor)
+
+	for _, movie := range []models.Movie{
+		{Title: "Heat", Year: 1995, Rating: 8.3},
+		{Title: "Alien", Year: 1979, Rating: 8.1},
+		{Title: "Casino", Year: 1995, Rating: 7.5},
+		{Title: "Dune", Year: 2021, Rating: 6.9},
+		{Title: "Birthday Video", Year: 1995},
+	} {
+		w := serveJSON(router, "POST", "/movies", token, movie)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	type page struct {
+		Data []models.Movie `json:"data"`
+		Meta map[string]interface{}
+	}
+	fetch := func(path string, params url.Values) page {
+		w := serveJSON(router, "GET", path+"?"+params.Encode(), token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response page
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+	titles := func(movies []models.Movie) []string {
+		result := make([]string, len(movies))
+		for i, movie := range movies {
+			result[i] = movie.Title
+		}
+		return result
+	}
+	// walk blättert mit next_cursor bis zum Ende und mit prev_cursor wieder zurück
+	walk := func(t *testing.T, path string, params url.Values) (forward, backward []string) {
+		params.Set("limit", "2")
+		params.Set("cursor", "")
+		var last page
+		for i := 0; i < 10; i++ {
+			last = fetch(path, params)
+			forward = append(forward, titles(last.Data)...)
+			next, ok := last.Meta["next_cursor"].(string)
+			if !ok {
+				break
+			}
+			params.Set("cursor", next)
+		}
+		backward = titles(last.Data)
+		for i := 0; i < 10; i++ {
+			prev, ok := last.Meta["prev_cursor"].(string)
+			if !ok {
+				break
+			}
+			params.Set("cursor", prev)
+			last = fetch(path, params)
+			backward = append(titles(last.Data), backward...)
+		}
+		return forward, backward
+	}
+
+	t.Run("Sort Orders", func(t *testing.T) {
+		for _, tc := range []struct {
+			query    url.Values
+			expected []string
+		}{
+			{url.Values{}, []string{"Alien", "Birthday Video", "Casino", "Dune", "Heat"}},
+			{url.Values{"order": {"desc"}}, []string{"Heat", "Dune", "Casino", "Birthday Video", "Alien"}},
+			{url.Values{"sort": {"year"}}, []string{"Alien", "Heat", "Casino", "Birthday Video", "Dune"}},
+			{url.Values{"sort": {"rating"}, "order": {"desc"}}, []string{"Heat", "Alien", "Casino", "Dune", "Birthday Video"}},
+			{url.Values{"sort": {"created_at"}, "order": {"desc"}}, []string{"Birthday Video", "Dune", "Casino", "Alien", "Heat"}},
+		} {
+			forward, backward := walk(t, "/movies", tc.query)
+			assert.Equal(t, tc.expected, forward, tc.query.Encode())
+			assert.Equal(t, tc.expected, backward, tc.query.Encode())
+		}
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		forward, backward := walk(t, "/movies/search", url.Values{"q": {"year:1995"}})
+		assert.Equal(t, []string{"Birthday Video", "Casino", "Heat"}, forward)
+		assert.Equal(t, forward, backward)
+	})
+
+	t.Run("Meta", func(t *testing.T) {
+		first := fetch("/movies", url.Values{"cursor": {""}, "limit": {"2"}})
+		assert.EqualValues(t, 5, first.Meta["total"])
+		assert.NotContains(t, first.Meta, "page")
+		assert.NotContains(t, first.Meta, "prev_cursor")
+		assert.Contains(t, first.Meta, "next_cursor")
+
+		untotaled := fetch("/movies", url.Values{"cursor": {""}, "include_total": {"false"}})
+		assert.NotContains(t, untotaled.Meta, "total")
+		assert.NotContains(t, untotaled.Meta, "next_cursor")
+		assert.Len(t, untotaled.Data, 5)
+
+		paged := fetch("/movies", url.Values{"page": {"2"}, "limit": {"2"}, "include_total": {"false"}})
+		assert.Equal(t, []string{"Casino", "Dune"}, titles(paged.Data))
+		assert.EqualValues(t, 2, paged.Meta["page"])
+		assert.NotContains(t, paged.Meta, "total")
+		assert.NotContains(t, paged.Meta, "total_pages")
+	})
+
+	t.Run("Invalid Cursor", func(t *testing.T) {
+		first := fetch("/movies", url.Values{"cursor": {""}, "limit": {"2"}})
+		next := first.Meta["next_cursor"].(string)
+
+		for _, path := range []string{
+			"/movies?cursor=kaputt",
+			"/movies?cursor=" + next + "&sort=year",
+			"/movies?cursor=" + next + "&order=desc",
+			"/movies?include_total=vielleicht",
+			"/movies/search?q=alien&fuzzy=true&cursor=",
+		} {
+			w := serveJSON(router, "GET", path, token, nil)
+			assert.Equal(t, http.StatusBadRequest, w.Code, path)
+		}
+	})
+}
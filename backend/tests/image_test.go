@@ -5,6 +5,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/MichaelKlank/movie-collector/backend/auth"
 	"github.com/MichaelKlank/movie-collector/backend/testutil"
 	"github.com/stretchr/testify/assert"
 )
@@ -12,9 +13,11 @@ import (
 func TestImage(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	router := testutil.SetupRouter(db)
+	_, token := testutil.CreateTestUser(t, db, "tester", auth.RoleAdmin)
 
 	t.Run("Test Image Upload", func(t *testing.T) {
 		req := httptest.NewRequest("POST", "/movies/1/image", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
 		w := httptest.NewRecorder()
 		router.ServeHTTP(w, req)
 
@@ -31,9 +34,18 @@ func TestImage(t *testing.T) {
 
 	t.Run("Test Image Delete", func(t *testing.T) {
 		req := httptest.NewRequest("DELETE", "/movies/1/image", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
 		w := httptest.NewRecorder()
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
+
+	t.Run("Test Image Delete Without Token", func(t *testing.T) {
+		req := httptest.NewRequest("DELETE", "/movies/1/image", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
 }
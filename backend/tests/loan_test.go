@@ -0,0 +1,173 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func decodeLoans(t *testing.T, body []byte) []models.Loan {
+	var loans []models.Loan
+	require.NoError(t, json.Unmarshal(body, &loans))
+	return loans
+}
+
+func TestLoans(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	router := testutil.SetupRouter(db)
+	_, token := testutil.CreateTestUser(t, db, "collector", auth.RoleAdmin)
+	_, otherToken := testutil.CreateTestUser(t, db, "neighbour", auth.RoleAdmin)
+
+	var movies []models.Movie
+	for _, title := range []string{"Jaws", "Heat"} {
+		w := serveJSON(router, "POST", "/movies", token, models.Movie{Title: title, Year: 1975})
+		require.Equal(t, http.StatusCreated, w.Code)
+		var movie models.Movie
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &movie))
+		movies = append(movies, movie)
+	}
+	jawsPath := fmt.Sprintf("/movies/%d", movies[0].ID)
+	heatPath := fmt.Sprintf("/movies/%d", movies[1].ID)
+
+	yesterday := time.Now().AddDate(0, 0, -1).Format(models.LoanDateLayout)
+	lastMonth := time.Now().AddDate(0, -1, 0).Format(models.LoanDateLayout)
+	nextWeek := time.Now().AddDate(0, 0, 7).Format(models.LoanDateLayout)
+
+	t.Run("Lend", func(t *testing.T) {
+		w := serveJSON(router, "POST", jawsPath+"/loans", token, models.LoanRequest{
+			BorrowerName:    "Quint",
+			BorrowerContact: "quint@example.com",
+			LentOn:          lastMonth,
+			DueOn:           yesterday,
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		w = serveJSON(router, "POST", heatPath+"/loans", token, models.LoanRequest{BorrowerName: "Vincent", DueOn: nextWeek})
+		require.Equal(t, http.StatusCreated, w.Code)
+		var loan models.Loan
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &loan))
+		assert.Equal(t, time.Now().Format(models.LoanDateLayout), loan.LentOn)
+	})
+
+	t.Run("Invalid Loans", func(t *testing.T) {
+		w := serveJSON(router, "POST", jawsPath+"/loans", token, models.LoanRequest{BorrowerName: "Brody"})
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = serveJSON(router, "POST", jawsPath+"/loans", token, models.LoanRequest{})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = serveJSON(router, "POST", heatPath+"/loans", token, models.LoanRequest{BorrowerName: "Neil", DueOn: "next friday"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = serveJSON(router, "POST", jawsPath+"/loans", otherToken, models.LoanRequest{BorrowerName: "Hooper"})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Movie Shows Active Loan", func(t *testing.T) {
+		w := serveJSON(router, "GET", jawsPath, token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var movie models.Movie
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &movie))
+		require.NotNil(t, movie.ActiveLoan)
+		assert.Equal(t, "Quint", movie.ActiveLoan.BorrowerName)
+	})
+
+	t.Run("Active And Overdue Lists", func(t *testing.T) {
+		w := serveJSON(router, "GET", "/loans", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		loans := decodeLoans(t, w.Body.Bytes())
+		require.Len(t, loans, 2)
+		require.NotNil(t, loans[0].Movie)
+		assert.Equal(t, "Jaws", loans[0].Movie.Title)
+
+		w = serveJSON(router, "GET", "/loans/overdue", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		loans = decodeLoans(t, w.Body.Bytes())
+		require.Len(t, loans, 1)
+		assert.Equal(t, "Quint", loans[0].BorrowerName)
+
+		w = serveJSON(router, "GET", "/loans", otherToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, decodeLoans(t, w.Body.Bytes()))
+	})
+
+	t.Run("Return", func(t *testing.T) {
+		w := serveJSON(router, "POST", jawsPath+"/return", token, models.ReturnRequest{ReturnedOn: "1999-01-01"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = serveJSON(router, "POST", jawsPath+"/return", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var loan models.Loan
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &loan))
+		assert.Equal(t, time.Now().Format(models.LoanDateLayout), loan.ReturnedOn)
+
+		w = serveJSON(router, "POST", jawsPath+"/return", token, nil)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = serveJSON(router, "GET", jawsPath, token, nil)
+		var movie models.Movie
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &movie))
+		assert.Nil(t, movie.ActiveLoan)
+
+		w = serveJSON(router, "GET", "/loans/overdue", token, nil)
+		assert.Empty(t, decodeLoans(t, w.Body.Bytes()))
+	})
+
+	t.Run("History", func(t *testing.T) {
+		w := serveJSON(router, "POST", jawsPath+"/loans", token, models.LoanRequest{BorrowerName: "Hooper"})
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		w = serveJSON(router, "GET", jawsPath+"/loans", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		loans := decodeLoans(t, w.Body.Bytes())
+		require.Len(t, loans, 2)
+		assert.Equal(t, "Hooper", loans[0].BorrowerName)
+		assert.Equal(t, "Quint", loans[1].BorrowerName)
+		assert.NotEmpty(t, loans[1].ReturnedOn)
+	})
+	t.Run("Anonymous Requests Are Rejected", func(t *testing.T) {
+		for _, path := range []string{"/loans", "/loans/overdue", jawsPath + "/loans"} {
+			w := serveJSON(router, "GET", path, "", nil)
+			assert.Equal(t, http.StatusUnauthorized, w.Code, path)
+		}
+	})
+}
+
+func TestConcurrentLend(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	router := testutil.SetupRouter(db)
+	_, token := testutil.CreateTestUser(t, db, "collector", auth.RoleAdmin)
+
+	w := serveJSON(router, "POST", "/movies", token, models.Movie{Title: "Jaws", Year: 1975})
+	require.Equal(t, http.StatusCreated, w.Code)
+	var movie models.Movie
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &movie))
+
+	// Eine gleichzeitige Ausleihe entsteht direkt nach der Prüfung auf eine laufende Ausleihe
+	raced := false
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:race_lend", func(tx *gorm.DB) {
+		if _, ok := tx.Statement.Dest.(*models.Movie); ok && !raced {
+			raced = true
+			assert.NoError(t, db.Exec("INSERT INTO loans (movie_id, owner_id, borrower_name, lent_on, returned_on) VALUES (?, ?, ?, ?, '')",
+				movie.ID, movie.OwnerID, "Quint", "2024-03-01").Error)
+		}
+	}))
+	defer func() { _ = db.Callback().Query().Remove("test:race_lend") }()
+
+	w = serveJSON(router, "POST", fmt.Sprintf("/movies/%d/loans", movie.ID), token, models.LoanRequest{BorrowerName: "Hooper"})
+	assert.True(t, raced)
+	assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+
+	var active int64
+	require.NoError(t, db.Model(&models.Loan{}).Where("movie_id = ? AND returned_on = ''", movie.ID).Count(&active).Error)
+	assert.Equal(t, int64(1), active)
+}
@@ -0,0 +1,133 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocations(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	router := testutil.SetupRouter(db)
+	_, token := testutil.CreateTestUser(t, db, "collector", auth.RoleAdmin)
+	_, otherToken := testutil.CreateTestUser(t, db, "neighbour", auth.RoleAdmin)
+
+	createLocation := func(token string, location models.Location) models.Location {
+		w := serveJSON(router, "POST", "/locations", token, location)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var created models.Location
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		return created
+	}
+	createMovie := func(movie models.Movie) models.Movie {
+		w := serveJSON(router, "POST", "/movies", token, movie)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var created models.Movie
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		return created
+	}
+
+	shelf := createLocation(token, models.Location{Room: "Wohnzimmer", Shelf: "Regal 2", Slot: "Fach 3"})
+	basement := createLocation(token, models.Location{Room: "Keller", Shelf: "Umzugskarton"})
+	foreign := createLocation(otherToken, models.Location{Room: "Nachbarhaus"})
+
+	t.Run("Validation", func(t *testing.T) {
+		w := serveJSON(router, "POST", "/locations", token, models.Location{Shelf: "Regal 1"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("List Is Scoped To The User", func(t *testing.T) {
+		w := serveJSON(router, "GET", "/locations", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var locations []models.Location
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &locations))
+		require.Len(t, locations, 2)
+		assert.Equal(t, "Keller", locations[0].Room)
+
+		w = serveJSON(router, "GET", fmt.Sprintf("/locations/%d", foreign.ID), token, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	alien := createMovie(models.Movie{Title: "Alien", Year: 1979, LocationID: &shelf.ID})
+	heat := createMovie(models.Movie{Title: "Heat", Year: 1995})
+
+	t.Run("Assign Movies And Copies", func(t *testing.T) {
+		require.NotNil(t, alien.Location)
+		assert.Equal(t, "Regal 2", alien.Location.Shelf)
+
+		w := serveJSON(router, "POST", fmt.Sprintf("/movies/%d/copies", heat.ID), token, models.Copy{Format: models.FormatDVD, LocationID: &basement.ID})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var item models.Copy
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &item))
+		require.NotNil(t, item.Location)
+		assert.Equal(t, "Keller", item.Location.Room)
+
+		w = serveJSON(router, "POST", "/movies", token, models.Movie{Title: "Sneaky", Year: 2000, LocationID: &foreign.ID})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = serveJSON(router, "POST", fmt.Sprintf("/movies/%d/copies", heat.ID), token, models.Copy{Format: models.FormatDVD, LocationID: &foreign.ID})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Movies At Location", func(t *testing.T) {
+		w := serveJSON(router, "GET", fmt.Sprintf("/locations/%d/movies", shelf.ID), token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"Alien"}, movieTitles(t, w))
+
+		w = serveJSON(router, "GET", fmt.Sprintf("/locations/%d/movies", basement.ID), token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"Heat"}, movieTitles(t, w))
+
+		w = serveJSON(router, "GET", fmt.Sprintf("/locations/%d/movies", foreign.ID), token, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Anonymous Reads See No Shelves", func(t *testing.T) {
+		w := serveJSON(router, "GET", "/locations", "", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var locations []models.Location
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &locations))
+		assert.Empty(t, locations)
+
+		w = serveJSON(router, "GET", fmt.Sprintf("/locations/%d", shelf.ID), "", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		w = serveJSON(router, "GET", fmt.Sprintf("/locations/%d/movies", shelf.ID), "", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Search Hits Include Location", func(t *testing.T) {
+		w := serveJSON(router, "GET", "/movies/search?q=alien", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data []models.Movie `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+		require.NotNil(t, response.Data[0].Location)
+		assert.Equal(t, "Wohnzimmer", response.Data[0].Location.Room)
+
+		w = serveJSON(router, "GET", "/movies/search?q=heat", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+		require.Len(t, response.Data[0].Copies, 1)
+		require.NotNil(t, response.Data[0].Copies[0].Location)
+		assert.Equal(t, "Keller", response.Data[0].Copies[0].Location.Room)
+	})
+
+	t.Run("Delete Unassigns", func(t *testing.T) {
+		w := serveJSON(router, "DELETE", fmt.Sprintf("/locations/%d", shelf.ID), token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var stored models.Movie
+		require.NoError(t, db.First(&stored, alien.ID).Error)
+		assert.Nil(t, stored.LocationID)
+	})
+}
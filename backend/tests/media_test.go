@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMovieMediaFields(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	router := testutil.SetupRouter(db)
+	_, token := testutil.CreateTestUser(t, db, "collector", auth.RoleAdmin)
+
+	collection := []models.Movie{
+		{Title: "Blade Runner", Year: 1982, Format: models.FormatUHD, Region: models.RegionFree, DiscCount: 3, Edition: "Final Cut Steelbook"},
+		{Title: "Alien", Year: 1979, Format: models.FormatBluray, Region: "B", DiscCount: 1},
+		{Title: "Aliens", Year: 1986, Format: models.FormatDVD, Region: "2", DiscCount: 2, Edition: "Special Edition"},
+		{Title: "Arrival", Year: 2016, Format: models.FormatDigital},
+	}
+	for _, movie := range collection {
+		w := serveJSON(router, "POST", "/movies", token, movie)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	t.Run("Fields Are Stored", func(t *testing.T) {
+		var stored models.Movie
+		require.NoError(t, db.Where("title = ?", "Blade Runner").First(&stored).Error)
+		assert.Equal(t, models.FormatUHD, stored.Format)
+		assert.Equal(t, models.RegionFree, stored.Region)
+		assert.Equal(t, 3, stored.DiscCount)
+		assert.Equal(t, "Final Cut Steelbook", stored.Edition)
+	})
+
+	t.Run("Invalid Media Is Rejected", func(t *testing.T) {
+		invalid := []models.Movie{
+			{Title: "Unknown Format", Year: 2000, Format: "vhs"},
+			{Title: "Unknown Region", Year: 2000, Format: models.FormatDVD, Region: "Z"},
+			{Title: "DVD With Blu-ray Region", Year: 2000, Format: models.FormatDVD, Region: "A"},
+			{Title: "Blu-ray With DVD Region", Year: 2000, Format: models.FormatBluray, Region: "2"},
+			{Title: "Digital With Discs", Year: 2000, Format: models.FormatDigital, DiscCount: 1},
+			{Title: "Region Without Format", Year: 2000, Region: "2"},
+			{Title: "No Discs", Year: 2000, Format: models.FormatDVD, DiscCount: -1},
+		}
+		for _, movie := range invalid {
+			w := serveJSON(router, "POST", "/movies", token, movie)
+			assert.Equal(t, http.StatusBadRequest, w.Code, movie.Title)
+
+			var response models.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.NotEmpty(t, response.Error)
+		}
+	})
+
+	t.Run("Filter Movie List", func(t *testing.T) {
+		w := serveJSON(router, "GET", "/movies?format=bluray", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"Alien"}, movieTitles(t, w))
+
+		w = serveJSON(router, "GET", "/movies?edition=steelbook", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"Blade Runner"}, movieTitles(t, w))
+
+		w = serveJSON(router, "GET", "/movies?region=2&disc_count=2", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"Aliens"}, movieTitles(t, w))
+
+		w = serveJSON(router, "GET", "/movies?format=laserdisc", token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Filter Search Results", func(t *testing.T) {
+		w := serveJSON(router, "GET", "/movies/search?q=alien", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.ElementsMatch(t, []string{"Alien", "Aliens"}, movieTitles(t, w))
+
+		w = serveJSON(router, "GET", "/movies/search?q=alien&format=dvd", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"Aliens"}, movieTitles(t, w))
+	})
+}
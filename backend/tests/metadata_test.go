@@ -0,0 +1,247 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/repositories"
+	"github.com/MichaelKlank/movie-collector/backend/services"
+	"github.com/MichaelKlank/movie-collector/backend/testutil"
+	"github.com/MichaelKlank/movie-collector/backend/tmdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const matrixDetails = `{
+	"id": 603,
+	"title": "Matrix",
+	"original_title": "The Matrix",
+	"overview": "Ein Hacker erfährt, dass seine Welt eine Simulation ist.",
+	"runtime": 136,
+	"genres": [{"id": 28, "name": "Action"}, {"id": 878, "name": "Science Fiction"}],
+	"credits": {
+		"cast": [
+			{"id": 6384, "name": "Keanu Reeves", "character": "Neo", "order": 0},
+			{"id": 2975, "name": "Laurence Fishburne", "character": "Morpheus", "order": 1}
+		],
+		"crew": [
+			{"id": 9339, "name": "Lana Wachowski", "job": "Director", "department": "Directing"},
+			{"id": 1091, "name": "Catering Person", "job": "Catering", "department": "Crew"}
+		]
+	}
+}`
+
+const johnWickDetails = `{
+	"id": 245891,
+	"title": "John Wick",
+	"runtime": 101,
+	"genres": [{"id": 28, "name": "Action"}],
+	"credits": {
+		"cast": [{"id": 6384, "name": "Keanu Reeves", "character": "John Wick", "order": 0}],
+		"crew": []
+	}
+}`
+
+func TestGetMovieDetailsAppendsCredits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "credits", r.URL.Query().Get("append_to_response"))
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(matrixDetails))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	movie, err := tmdb.NewClientWithBaseURL(server.URL).GetMovieDetails(603)
+	require.NoError(t, err)
+	assert.Equal(t, 136, movie.Runtime)
+	assert.Len(t, movie.Genres, 2)
+	require.NotNil(t, movie.Credits)
+	assert.Equal(t, "Neo", movie.Credits.Cast[0].Character)
+	assert.Equal(t, "Director", movie.Credits.Crew[0].Job)
+}
+
+func TestSyncMovieMetadata(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/movie/603":
+			_, _ = w.Write([]byte(matrixDetails))
+		case "/movie/245891":
+			_, _ = w.Write([]byte(johnWickDetails))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	movieRepo := repositories.NewMovieRepository(db)
+	service := services.NewMetadataService(tmdb.NewClientWithBaseURL(server.URL), movieRepo, repositories.NewMetadataRepository(db))
+
+	matrix := models.Movie{Title: "Matrix", Year: 1999, TMDBId: "603"}
+	wick := models.Movie{Title: "John Wick", Year: 2014, TMDBId: "245891"}
+	unlinked := models.Movie{Title: "Home Video", Year: 2001}
+	for _, movie := range []*models.Movie{&matrix, &wick, &unlinked} {
+		require.NoError(t, movieRepo.Create(movie))
+	}
+
+	t.Run("Stores Genres Runtime And Credits", func(t *testing.T) {
+		movie, err := service.SyncMovie(context.Background(), 0, matrix.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 136, movie.Runtime)
+		assert.Equal(t, "The Matrix", movie.OriginalTitle)
+		require.Len(t, movie.Genres, 2)
+		assert.Equal(t, "Action", movie.Genres[0].Name)
+
+		// Nur wichtige Aufgaben im Stab werden übernommen
+		require.Len(t, movie.Credits, 3)
+		assert.Equal(t, models.CreditCast, movie.Credits[0].Kind)
+		assert.Equal(t, "Keanu Reeves", movie.Credits[0].Person.Name)
+		assert.Equal(t, "Neo", movie.Credits[0].Character)
+		assert.Equal(t, models.CreditCrew, movie.Credits[2].Kind)
+		assert.Equal(t, "Lana Wachowski", movie.Credits[2].Person.Name)
+	})
+
+	t.Run("Resync Replaces Instead Of Duplicating", func(t *testing.T) {
+		_, err := service.SyncMovie(context.Background(), 0, matrix.ID)
+		require.NoError(t, err)
+
+		var credits, links int64
+		db.Model(&models.Credit{}).Where("movie_id = ?", matrix.ID).Count(&credits)
+		db.Table("movie_genres").Where("movie_id = ?", matrix.ID).Count(&links)
+		assert.Equal(t, int64(3), credits)
+		assert.Equal(t, int64(2), links)
+	})
+
+	t.Run("People And Genres Are Shared Between Movies", func(t *testing.T) {
+		_, err := service.SyncMovie(context.Background(), 0, wick.ID)
+		require.NoError(t, err)
+
+		var people, genres int64
+		db.Model(&models.Person{}).Where("tmdb_id = ?", 6384).Count(&people)
+		db.Model(&models.Genre{}).Where("tmdb_id = ?", 28).Count(&genres)
+		assert.Equal(t, int64(1), people)
+		assert.Equal(t, int64(1), genres)
+	})
+
+	t.Run("Unlinked Movie", func(t *testing.T) {
+		_, err := service.SyncMovie(context.Background(), 0, unlinked.ID)
+		assert.ErrorIs(t, err, services.ErrNotLinkedToTMDB)
+	})
+
+	t.Run("Delete Removes Metadata", func(t *testing.T) {
+		require.NoError(t, movieRepo.Delete(0, wick.ID))
+
+		var credits, links int64
+		db.Model(&models.Credit{}).Where("movie_id = ?", wick.ID).Count(&credits)
+		db.Table("movie_genres").Where("movie_id = ?", wick.ID).Count(&links)
+		assert.Zero(t, credits)
+		assert.Zero(t, links)
+	})
+}
+
+func TestSyncMetadataOnLink(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/movie/603":
+			_, _ = w.Write([]byte(matrixDetails))
+		case "/movie/245891":
+			_, _ = w.Write([]byte(johnWickDetails))
+		case "/movie/1000", "/movie/1001":
+			// TMDB antwortet nicht, bis die Anfrage abgebrochen wird oder der Test endet
+			select {
+			case <-r.Context().Done():
+			case <-release:
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	movieRepo := repositories.NewMovieRepository(db)
+	movieService := services.NewMovieService(movieRepo, repositories.NewLocationRepository(db))
+	movieService.UseMetadata(services.NewMetadataService(tmdb.NewClientWithBaseURL(server.URL), movieRepo, repositories.NewMetadataRepository(db)))
+
+	t.Run("Create With TMDB ID", func(t *testing.T) {
+		movie := models.Movie{Title: "Matrix", Year: 1999, TMDBId: "603"}
+		require.NoError(t, movieService.CreateMovie(context.Background(), 0, &movie))
+		assert.Equal(t, 136, movie.Runtime)
+		assert.Equal(t, "The Matrix", movie.OriginalTitle)
+		assert.Len(t, movie.Genres, 2)
+		assert.Len(t, movie.Credits, 3)
+	})
+
+	t.Run("Link Existing Movie", func(t *testing.T) {
+		movie := models.Movie{Title: "John Wick", Year: 2014}
+		require.NoError(t, movieService.CreateMovie(context.Background(), 0, &movie))
+		assert.Empty(t, movie.Genres)
+
+		movie.TMDBId = "245891"
+		require.NoError(t, movieService.UpdateMovie(context.Background(), 0, &movie))
+		assert.Equal(t, 101, movie.Runtime)
+		require.Len(t, movie.Genres, 1)
+		assert.Equal(t, "Action", movie.Genres[0].Name)
+	})
+
+	t.Run("TMDB Errors Keep The Movie", func(t *testing.T) {
+		movie := models.Movie{Title: "Unbekannt", Year: 2000, TMDBId: "999"}
+		require.NoError(t, movieService.CreateMovie(context.Background(), 0, &movie))
+		assert.NotZero(t, movie.ID)
+		assert.Empty(t, movie.Genres)
+
+		stored, err := movieRepo.GetByID(0, movie.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "999", stored.TMDBId)
+	})
+
+	t.Run("Slow TMDB Does Not Block Creation", func(t *testing.T) {
+		movieService.MetadataTimeout = 100 * time.Millisecond
+		defer func() { movieService.MetadataTimeout = services.DefaultMetadataTimeout }()
+
+		start := time.Now()
+		movie := models.Movie{Title: "Langsam", Year: 2001, TMDBId: "1000"}
+		require.NoError(t, movieService.CreateMovie(context.Background(), 0, &movie))
+		assert.Less(t, time.Since(start), 2*time.Second)
+		assert.NotZero(t, movie.ID)
+		assert.Empty(t, movie.Genres)
+
+		// Eine abgebrochene Anfrage bricht auch den Abruf ab
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		movieService.MetadataTimeout = services.DefaultMetadataTimeout
+		start = time.Now()
+		movie = models.Movie{Title: "Abgebrochen", Year: 2002, TMDBId: "1001"}
+		require.NoError(t, movieService.CreateMovie(ctx, 0, &movie))
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
+
+func TestSyncMovieMetadataRoute(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	router := testutil.SetupRouter(db)
+	_, editor := testutil.CreateTestUser(t, db, "editor", auth.RoleEditor)
+	_, viewer := testutil.CreateTestUser(t, db, "viewer", auth.RoleViewer)
+
+	w := serveJSON(router, "POST", "/movies", editor, models.Movie{Title: "Home Video", Year: 2001})
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w = serveJSON(router, "POST", "/movies/1/metadata", viewer, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = serveJSON(router, "POST", "/movies/1/metadata", editor, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = serveJSON(router, "POST", fmt.Sprintf("/movies/%d/metadata", 999), editor, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMovieListSortingFiltersAndFacets(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	router := testutil.SetupRouter(db)
+	_, token := testutil.CreateTestUser(t, db, "collector", auth.RoleEditor)
+
+	for _, movie := range []models.Movie{
+		{Title: "Heat", Year: 1995, Rating: 8.3, TMDBId: "949", PosterPath: "/heat.jpg"},
+		{Title: "Alien", Year: 1979, Rating: 8.1, TMDBId: "348"},
+		{Title: "Casino", Year: 1995, Rating: 7.5},
+		{Title: "Dune", Year: 2021, Rating: 6.9, PosterPath: "/dune.jpg"},
+		{Title: "Birthday Video", Year: 2003},
+	} {
+		w := serveJSON(router, "POST", "/movies", token, movie)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	list := func(query string) []string {
+		w := serveJSON(router, "GET", "/movies"+query, token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		return movieTitles(t, w)
+	}
+
+	t.Run("Sorting", func(t *testing.T) {
+		assert.Equal(t, []string{"Alien", "Birthday Video", "Casino", "Dune", "Heat"}, list(""))
+		assert.Equal(t, []string{"Heat", "Dune", "Casino", "Birthday Video", "Alien"}, list("?order=desc"))
+		assert.Equal(t, []string{"Alien", "Heat", "Casino", "Birthday Video", "Dune"}, list("?sort=year"))
+		assert.Equal(t, []string{"Heat", "Alien", "Casino", "Dune", "Birthday Video"}, list("?sort=rating&order=desc"))
+		assert.Equal(t, []string{"Birthday Video", "Dune", "Casino", "Alien", "Heat"}, list("?sort=created_at&order=desc"))
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		assert.Equal(t, []string{"Birthday Video", "Casino", "Heat"}, list("?year_from=1990&year_to=2010"))
+		assert.Equal(t, []string{"Alien", "Heat"}, list("?rating_min=8"))
+		assert.Equal(t, []string{"Dune", "Heat"}, list("?has_image=true"))
+		assert.Equal(t, []string{"Alien", "Birthday Video", "Casino"}, list("?has_image=false"))
+		assert.Equal(t, []string{"Alien", "Heat"}, list("?tmdb_linked=true"))
+		assert.Equal(t, []string{"Birthday Video", "Casino", "Dune"}, list("?tmdb_linked=false"))
+	})
+
+	t.Run("Invalid Parameters", func(t *testing.T) {
+		for _, query := range []string{"?sort=director", "?order=up", "?year_from=2000&year_to=1990", "?rating_min=11", "?has_image=maybe"} {
+			w := serveJSON(router, "GET", "/movies"+query, token, nil)
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+
+	t.Run("Facets", func(t *testing.T) {
+		w := serveJSON(router, "GET", "/movies", token, nil)
+		var response models.PaginatedResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.NotNil(t, response.Meta.Facets)
+
+		assert.Equal(t, []models.DecadeFacet{
+			{Decade: 1970, Count: 1},
+			{Decade: 1990, Count: 2},
+			{Decade: 2000, Count: 1},
+			{Decade: 2020, Count: 1},
+		}, response.Meta.Facets.Decades)
+
+		counts := map[string]int64{}
+		for _, band := range response.Meta.Facets.Ratings {
+			counts[band.Band] = band.Count
+		}
+		assert.Equal(t, map[string]int64{"0-5": 0, "5-6": 0, "6-7": 1, "7-8": 1, "8-10": 2}, counts)
+
+		// Facetten berücksichtigen die aktiven Filter
+		w = serveJSON(router, "GET", "/movies?year_from=1990&year_to=1999", token, nil)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, []models.DecadeFacet{{Decade: 1990, Count: 2}}, response.Meta.Facets.Decades)
+
+		// Ohne Gesamtanzahl entfallen auch die Facetten
+		w = serveJSON(router, "GET", "/movies?include_total=false", token, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var untotaled models.PaginatedResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &untotaled))
+		assert.Nil(t, untotaled.Meta.Facets)
+		assert.Len(t, untotaled.Data, 5)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/MichaelKlank/movie-collector/backend/auth"
 	"github.com/MichaelKlank/movie-collector/backend/models"
 	"github.com/MichaelKlank/movie-collector/backend/testutil"
 	"github.com/stretchr/testify/assert"
@@ -16,6 +17,7 @@ import (
 func TestMovieCRUD(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	router := testutil.SetupRouter(db)
+	_, token := testutil.CreateTestUser(t, db, "tester", auth.RoleAdmin)
 
 	t.Run("Create Movie", func(t *testing.T) {
 		movie := models.Movie{
@@ -25,6 +27,7 @@ func TestMovieCRUD(t *testing.T) {
 		}
 		jsonData, _ := json.Marshal(movie)
 		req := httptest.NewRequest("POST", "/movies", bytes.NewBuffer(jsonData))
+		req.Header.Set("Authorization", "Bearer "+token)
 		req.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
 		router.ServeHTTP(w, req)
@@ -39,8 +42,20 @@ func TestMovieCRUD(t *testing.T) {
 		assert.Equal(t, movie.Year, response.Year)
 	})
 
+	t.Run("Create Movie Without Token", func(t *testing.T) {
+		movie := models.Movie{Title: "Anonymous Movie", Year: 2024}
+		jsonData, _ := json.Marshal(movie)
+		req := httptest.NewRequest("POST", "/movies", bytes.NewBuffer(jsonData))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
 	t.Run("Get Movies", func(t *testing.T) {
 		req := httptest.NewRequest("GET", "/movies", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
 		w := httptest.NewRecorder()
 		router.ServeHTTP(w, req)
 
@@ -70,6 +85,7 @@ func TestMovieCRUD(t *testing.T) {
 
 	t.Run("Get Movie", func(t *testing.T) {
 		req := httptest.NewRequest("GET", "/movies/1", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
 		w := httptest.NewRecorder()
 		router.ServeHTTP(w, req)
 
@@ -91,6 +107,7 @@ func TestMovieCRUD(t *testing.T) {
 		}
 		jsonData, _ := json.Marshal(firstMovie)
 		req := httptest.NewRequest("POST", "/movies", bytes.NewBuffer(jsonData))
+		req.Header.Set("Authorization", "Bearer "+token)
 		req.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
 		router.ServeHTTP(w, req)
@@ -106,6 +123,7 @@ func TestMovieCRUD(t *testing.T) {
 		}
 		jsonData, _ = json.Marshal(secondMovie)
 		req = httptest.NewRequest("POST", "/movies", bytes.NewBuffer(jsonData))
+		req.Header.Set("Authorization", "Bearer "+token)
 		req.Header.Set("Content-Type", "application/json")
 		w = httptest.NewRecorder()
 		router.ServeHTTP(w, req)
@@ -127,6 +145,7 @@ func TestMovieCRUD(t *testing.T) {
 		}
 		jsonData, _ := json.Marshal(movie)
 		req := httptest.NewRequest("PUT", "/movies/1", bytes.NewBuffer(jsonData))
+		req.Header.Set("Authorization", "Bearer "+token)
 		req.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
 		router.ServeHTTP(w, req)
@@ -143,6 +162,7 @@ func TestMovieCRUD(t *testing.T) {
 		// Stellen sicher, dass der Cache nach dem Update ungültig ist
 		// und die aktualisierten Daten zurückgegeben werden
 		req = httptest.NewRequest("GET", "/movies/1", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
 		w = httptest.NewRecorder()
 		router.ServeHTTP(w, req)
 
@@ -157,6 +177,7 @@ func TestMovieCRUD(t *testing.T) {
 
 	t.Run("Cache-Control Headers Test", func(t *testing.T) {
 		req := httptest.NewRequest("GET", "/movies", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
 		w := httptest.NewRecorder()
 		router.ServeHTTP(w, req)
 
@@ -183,6 +204,7 @@ func TestMovieCRUD(t *testing.T) {
 		}
 		jsonData, _ := json.Marshal(movie)
 		req := httptest.NewRequest("POST", "/movies", bytes.NewBuffer(jsonData))
+		req.Header.Set("Authorization", "Bearer "+token)
 		req.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
 		router.ServeHTTP(w, req)
@@ -195,6 +217,7 @@ func TestMovieCRUD(t *testing.T) {
 
 		// Lösche den Film
 		req = httptest.NewRequest("DELETE", "/movies/"+strconv.Itoa(int(createdMovie.ID)), nil)
+		req.Header.Set("Authorization", "Bearer "+token)
 		w = httptest.NewRecorder()
 		router.ServeHTTP(w, req)
 
@@ -202,12 +225,14 @@ func TestMovieCRUD(t *testing.T) {
 
 		// Verifiziere, dass der Film gelöscht wurde und nach dem Löschen nicht mehr im Cache ist
 		req = httptest.NewRequest("GET", "/movies/"+strconv.Itoa(int(createdMovie.ID)), nil)
+		req.Header.Set("Authorization", "Bearer "+token)
 		w = httptest.NewRecorder()
 		router.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusNotFound, w.Code)
 
 		// Prüfe auch die Filmliste, um sicherzustellen, dass der Film dort nicht mehr erscheint
 		req = httptest.NewRequest("GET", "/movies", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
 		w = httptest.NewRecorder()
 		router.ServeHTTP(w, req)
 
@@ -0,0 +1,129 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveJSON(router http.Handler, method, path, token string, body interface{}) *httptest.ResponseRecorder {
+	var reader *bytes.Buffer
+	if body != nil {
+		jsonData, _ := json.Marshal(body)
+		reader = bytes.NewBuffer(jsonData)
+	} else {
+		reader = &bytes.Buffer{}
+	}
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func movieTitles(t *testing.T, w *httptest.ResponseRecorder) []string {
+	var response struct {
+		Data []models.Movie `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	titles := make([]string, 0, len(response.Data))
+	for _, movie := range response.Data {
+		titles = append(titles, movie.Title)
+	}
+	return titles
+}
+
+func TestPerUserCollections(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	router := testutil.SetupRouter(db)
+	alice, aliceToken := testutil.CreateTestUser(t, db, "alice", auth.RoleAdmin)
+	_, bobToken := testutil.CreateTestUser(t, db, "bob", auth.RoleAdmin)
+
+	w := serveJSON(router, "POST", "/movies", aliceToken, models.Movie{Title: "Alien", Year: 1979, TMDBId: "348"})
+	require.Equal(t, http.StatusCreated, w.Code)
+	var aliceMovie models.Movie
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &aliceMovie))
+	require.NotNil(t, aliceMovie.OwnerID)
+	assert.Equal(t, alice.ID, *aliceMovie.OwnerID)
+	aliceMoviePath := "/movies/" + strconv.Itoa(int(aliceMovie.ID))
+
+	t.Run("Owner Cannot Be Spoofed", func(t *testing.T) {
+		otherOwner := uint(999)
+		w := serveJSON(router, "POST", "/movies", bobToken, models.Movie{Title: "Heat", Year: 1995, OwnerID: &otherOwner})
+		require.Equal(t, http.StatusCreated, w.Code)
+		var movie models.Movie
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &movie))
+		require.NotNil(t, movie.OwnerID)
+		assert.NotEqual(t, otherOwner, *movie.OwnerID)
+	})
+
+	t.Run("Lists Are Scoped To The User", func(t *testing.T) {
+		w := serveJSON(router, "GET", "/movies", aliceToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"Alien"}, movieTitles(t, w))
+
+		w = serveJSON(router, "GET", "/movies", bobToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"Heat"}, movieTitles(t, w))
+	})
+
+	t.Run("Foreign Movies Are Not Visible", func(t *testing.T) {
+		w := serveJSON(router, "GET", aliceMoviePath, bobToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = serveJSON(router, "PUT", aliceMoviePath, bobToken, models.Movie{Title: "Hijacked", Year: 2000})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = serveJSON(router, "DELETE", aliceMoviePath, bobToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = serveJSON(router, "GET", aliceMoviePath, aliceToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Alien")
+	})
+
+	t.Run("Anonymous Reads See No User Collection", func(t *testing.T) {
+		w := serveJSON(router, "GET", "/movies", "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, movieTitles(t, w))
+
+		w = serveJSON(router, "GET", "/movies/search?q=alien", "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, movieTitles(t, w))
+
+		w = serveJSON(router, "GET", aliceMoviePath, "", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = serveJSON(router, "GET", aliceMoviePath+"/image", "", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Anonymous Reads See Unowned Movies", func(t *testing.T) {
+		require.NoError(t, db.Create(&models.Movie{Title: "Altbestand", Year: 1990}).Error)
+
+		// Eigene URL, da die Liste zuvor anonym zwischengespeichert wurde
+		w := serveJSON(router, "GET", "/movies?status=all", "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"Altbestand"}, movieTitles(t, w))
+	})
+
+	t.Run("Duplicate TMDB ID Is Checked Per User", func(t *testing.T) {
+		w := serveJSON(router, "POST", "/movies", bobToken, models.Movie{Title: "Alien", Year: 1979, TMDBId: "348"})
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		w = serveJSON(router, "POST", "/movies", aliceToken, models.Movie{Title: "Alien", Year: 1979, TMDBId: "348"})
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
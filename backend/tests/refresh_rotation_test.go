@@ -0,0 +1,170 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/cache"
+	"github.com/MichaelKlank/movie-collector/backend/repositories"
+	"github.com/MichaelKlank/movie-collector/backend/services"
+	"github.com/MichaelKlank/movie-collector/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func postWithRefreshCookie(router http.Handler, path string, refreshCookie *http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", path, nil)
+	if refreshCookie != nil {
+		req.AddCookie(refreshCookie)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func loginCookies(t *testing.T, router http.Handler, username, password string) (*http.Cookie, *http.Cookie) {
+	w := postCredentials(t, router, "/auth/login", username, password)
+	require.Equal(t, http.StatusOK, w.Code)
+	accessCookie := findCookie(w, auth.AccessTokenCookie)
+	refreshCookie := findCookie(w, auth.RefreshTokenCookie)
+	require.NotNil(t, accessCookie)
+	require.NotNil(t, refreshCookie)
+	return accessCookie, refreshCookie
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	router := testutil.SetupRouter(db)
+	require.Equal(t, http.StatusCreated, postCredentials(t, router, "/auth/register", "alice", "secret-password").Code)
+
+	t.Run("Refresh Rotates The Token", func(t *testing.T) {
+		_, first := loginCookies(t, router, "alice", "secret-password")
+
+		w := postWithRefreshCookie(router, "/auth/refresh", first)
+		require.Equal(t, http.StatusOK, w.Code)
+		second := findCookie(w, auth.RefreshTokenCookie)
+		require.NotNil(t, second)
+		assert.NotEqual(t, first.Value, second.Value)
+
+		w = postWithRefreshCookie(router, "/auth/refresh", second)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Reuse Revokes The Whole Family", func(t *testing.T) {
+		_, first := loginCookies(t, router, "alice", "secret-password")
+		_, otherSession := loginCookies(t, router, "alice", "secret-password")
+
+		w := postWithRefreshCookie(router, "/auth/refresh", first)
+		require.Equal(t, http.StatusOK, w.Code)
+		second := findCookie(w, auth.RefreshTokenCookie)
+
+		// Ein abgefangener, bereits verwendeter Token wird erneut vorgelegt
+		w = postWithRefreshCookie(router, "/auth/refresh", first)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		cleared := findCookie(w, auth.RefreshTokenCookie)
+		require.NotNil(t, cleared)
+		assert.Empty(t, cleared.Value)
+
+		// Auch der rechtmäßig rotierte Nachfolger ist damit ungültig
+		w = postWithRefreshCookie(router, "/auth/refresh", second)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		// Andere Anmeldungen desselben Benutzers sind nicht betroffen
+		w = postWithRefreshCookie(router, "/auth/refresh", otherSession)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Logout Revokes The Session", func(t *testing.T) {
+		_, refreshCookie := loginCookies(t, router, "alice", "secret-password")
+
+		w := postWithRefreshCookie(router, "/auth/logout", refreshCookie)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		w = postWithRefreshCookie(router, "/auth/refresh", refreshCookie)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Logout Everywhere", func(t *testing.T) {
+		accessCookie, laptop := loginCookies(t, router, "alice", "secret-password")
+		_, phone := loginCookies(t, router, "alice", "secret-password")
+
+		w := postWithRefreshCookie(router, "/auth/logout-all", nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		w = serveJSON(router, "POST", "/auth/logout-all", accessCookie.Value, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		for _, refreshCookie := range []*http.Cookie{laptop, phone} {
+			w = postWithRefreshCookie(router, "/auth/refresh", refreshCookie)
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+		}
+
+		// Nach einer erneuten Anmeldung funktioniert die Rotation wieder
+		_, fresh := loginCookies(t, router, "alice", "secret-password")
+		w = postWithRefreshCookie(router, "/auth/refresh", fresh)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestRevocationStores(t *testing.T) {
+	cache.InitRedisCache()
+	stores := map[string]services.RevocationStore{
+		"Cache":    cache.NewRevocationStore(),
+		"Database": repositories.NewRevocationRepository(testutil.SetupTestDB(t)),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			_, found, err := store.Get("missing")
+			require.NoError(t, err)
+			assert.False(t, found)
+
+			added, err := store.Add("used", 1, time.Hour)
+			require.NoError(t, err)
+			assert.True(t, added)
+
+			added, err = store.Add("used", 2, time.Hour)
+			require.NoError(t, err)
+			assert.False(t, added)
+
+			value, found, err := store.Get("used")
+			require.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, int64(1), value)
+
+			require.NoError(t, store.Set("generation", 3, 0))
+			require.NoError(t, store.Set("generation", 4, 0))
+			value, found, err = store.Get("generation")
+			require.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, int64(4), value)
+
+			value, err = store.Increment("generation")
+			require.NoError(t, err)
+			assert.Equal(t, int64(5), value)
+			value, err = store.Increment("counter")
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), value)
+
+			// Gleichzeitige Erhöhungen gehen nicht verloren
+			var wg sync.WaitGroup
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, err := store.Increment("counter")
+					assert.NoError(t, err)
+				}()
+			}
+			wg.Wait()
+			value, found, err = store.Get("counter")
+			require.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, int64(11), value)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleBasedAccess(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	router := testutil.SetupRouter(db)
+	viewer, viewerToken := testutil.CreateTestUser(t, db, "viewer", auth.RoleViewer)
+	_, editorToken := testutil.CreateTestUser(t, db, "editor", auth.RoleEditor)
+	_, adminToken := testutil.CreateTestUser(t, db, "admin", auth.RoleAdmin)
+
+	t.Run("Viewer Can Browse But Not Create", func(t *testing.T) {
+		w := serveJSON(router, "GET", "/movies", viewerToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = serveJSON(router, "POST", "/movies", viewerToken, models.Movie{Title: "Brazil", Year: 1985})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		var response models.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.NotEmpty(t, response.Error)
+	})
+
+	var moviePath string
+
+	t.Run("Editor Can Create And Update But Not Delete", func(t *testing.T) {
+		w := serveJSON(router, "POST", "/movies", editorToken, models.Movie{Title: "Brazil", Year: 1985})
+		require.Equal(t, http.StatusCreated, w.Code)
+		var movie models.Movie
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &movie))
+		moviePath = "/movies/" + strconv.Itoa(int(movie.ID))
+
+		w = serveJSON(router, "PUT", moviePath, editorToken, models.Movie{Title: "Brazil (Director's Cut)", Year: 1985})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = serveJSON(router, "DELETE", moviePath, editorToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = serveJSON(router, "DELETE", moviePath+"/image", editorToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Admin Routes", func(t *testing.T) {
+		for _, token := range []string{viewerToken, editorToken} {
+			w := serveJSON(router, "POST", "/cache/flush", token, nil)
+			assert.Equal(t, http.StatusForbidden, w.Code)
+
+			w = serveJSON(router, "GET", "/users", token, nil)
+			assert.Equal(t, http.StatusForbidden, w.Code)
+		}
+
+		w := serveJSON(router, "POST", "/cache/flush", "", nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		w = serveJSON(router, "GET", "/tmdb/test", "", nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		w = serveJSON(router, "POST", "/cache/flush", adminToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = serveJSON(router, "GET", "/users", adminToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var users []models.User
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &users))
+		assert.Len(t, users, 3)
+	})
+
+	t.Run("Admin Can Change Roles", func(t *testing.T) {
+		path := "/users/" + strconv.Itoa(int(viewer.ID)) + "/role"
+
+		w := serveJSON(router, "PUT", path, adminToken, models.RoleUpdate{Role: "superuser"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = serveJSON(router, "PUT", "/users/999/role", adminToken, models.RoleUpdate{Role: auth.RoleEditor})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = serveJSON(router, "PUT", path, adminToken, models.RoleUpdate{Role: auth.RoleEditor})
+		assert.Equal(t, http.StatusOK, w.Code)
+		var user models.User
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &user))
+		assert.Equal(t, auth.RoleEditor, user.Role)
+	})
+
+	t.Run("Role Changes Apply To Issued Tokens", func(t *testing.T) {
+		former, formerToken := testutil.CreateTestUser(t, db, "former-admin", auth.RoleAdmin)
+		w := serveJSON(router, "GET", "/users", formerToken, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		w = serveJSON(router, "PUT", "/users/"+strconv.Itoa(int(former.ID))+"/role", adminToken, models.RoleUpdate{Role: auth.RoleViewer})
+		require.Equal(t, http.StatusOK, w.Code)
+
+		// Der noch gültige Token trägt weiterhin die Rolle admin
+		w = serveJSON(router, "GET", "/users", formerToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		w = serveJSON(router, "POST", "/movies", formerToken, models.Movie{Title: "Brazil", Year: 1985})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		// Ein gelöschtes Konto verliert den Zugang ganz
+		require.NoError(t, db.Delete(&models.User{}, former.ID).Error)
+		w = serveJSON(router, "GET", "/movies", formerToken, nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Registration Assigns Roles", func(t *testing.T) {
+		db := testutil.SetupTestDB(t)
+		router := testutil.SetupRouter(db)
+
+		w := postCredentials(t, router, "/auth/register", "first", "first-password")
+		require.Equal(t, http.StatusCreated, w.Code)
+		var first models.User
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &first))
+		assert.Equal(t, auth.RoleAdmin, first.Role)
+
+		w = postCredentials(t, router, "/auth/register", "second", "second-password")
+		require.Equal(t, http.StatusCreated, w.Code)
+		var second models.User
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &second))
+		assert.Equal(t, auth.RoleEditor, second.Role)
+
+		// Neue Benutzer können ohne Zutun eines Administrators Filme in ihrer Sammlung anlegen
+		accessCookie, _ := loginCookies(t, router, "second", "second-password")
+		w = serveJSON(router, "POST", "/movies", accessCookie.Value, models.Movie{Title: "Alien", Year: 1979})
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+}
+
+func TestHasRole(t *testing.T) {
+	assert.True(t, auth.HasRole(auth.RoleAdmin, auth.RoleEditor))
+	assert.True(t, auth.HasRole(auth.RoleEditor, auth.RoleEditor))
+	assert.False(t, auth.HasRole(auth.RoleViewer, auth.RoleEditor))
+	assert.False(t, auth.HasRole("", auth.RoleViewer))
+	assert.False(t, auth.HasRole("superuser", auth.RoleViewer))
+}
@@ -0,0 +1,143 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/repositories"
+	"github.com/MichaelKlank/movie-collector/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	query, err := models.ParseSearchQuery(`year:1990..1999 rating:>7 format:BluRay director:"Ridley Scott" -horror alien`)
+	require.NoError(t, err)
+	assert.Equal(t, []models.QueryTerm{
+		{Field: models.QueryFieldYear, Op: models.QueryOpRange, Min: 1990, Max: 1999, HasMin: true, HasMax: true},
+		{Field: models.QueryFieldRating, Op: models.QueryOpGreater, Min: 7, HasMin: true},
+		{Field: models.QueryFieldFormat, Text: models.FormatBluray},
+		{Field: models.QueryFieldDirector, Text: "Ridley Scott"},
+		{Negate: true, Text: "horror"},
+		{Text: "alien"},
+	}, query.Terms)
+	assert.Equal(t, "alien", query.FreeText())
+	assert.Len(t, query.Conditions(), 5)
+
+	query, err = models.ParseSearchQuery(`Mission: Impossible - Fallout year:..2000 cast:Cruise`)
+	require.NoError(t, err)
+	assert.Equal(t, "Mission: Impossible - Fallout", query.FreeText())
+	assert.Equal(t, models.QueryTerm{Field: models.QueryFieldYear, Op: models.QueryOpRange, Max: 2000, HasMax: true}, query.Terms[4])
+	assert.Equal(t, models.QueryFieldActor, query.Terms[5].Field)
+
+	// Unbekannte Präfixe sind Teil des Titels
+	query, err = models.ParseSearchQuery(`Re:Zero yaer:1999 -Mission:Impossible`)
+	require.NoError(t, err)
+	assert.Equal(t, []models.QueryTerm{
+		{Text: "Re:Zero"},
+		{Text: "yaer:1999"},
+		{Negate: true, Text: "Mission:Impossible"},
+	}, query.Terms)
+
+	tests := []struct {
+		query    string
+		position int
+	}{
+		{`year:NaN`, 5},
+		{`rating:>Inf`, 8},
+		{`year:1e3`, 5},
+		{`runtime:0x10`, 8},
+		{`alien year:199x`, 11},
+		{`rating:>abc`, 8},
+		{`year:2000..1990`, 5},
+		{`year:1990..19x9`, 11},
+		{`format:vhs`, 7},
+		{`director:"Ridley Scott`, 9},
+		{`title:"Alien"s`, 13},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := models.ParseSearchQuery(tt.query)
+			var syntaxErr *models.QuerySyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+			assert.Equal(t, tt.position, syntaxErr.Position)
+		})
+	}
+}
+
+func TestSearchQueryLanguage(t *testing.T) {
+	database := testutil.SetupTestDB(t)
+	router := testutil.SetupRouter(database)
+	_, token := testutil.CreateTestUser(t, database, "collector", auth.RoleEditor)
+	metadataRepo := repositories.NewMetadataRepository(database)
+
+	scott := models.Credit{Person: &models.Person{TMDBId: 578, Name: "Ridley Scott"}, Kind: models.CreditCrew, Job: "Director"}
+	weaver := models.Credit{Person: &models.Person{TMDBId: 10205, Name: "Sigourney Weaver"}, Kind: models.CreditCast}
+	horror := models.Genre{TMDBId: 27, Name: "Horror"}
+	scifi := models.Genre{TMDBId: 878, Name: "Science Fiction"}
+
+	for _, seed := range []struct {
+		movie   models.Movie
+		genres  []models.Genre
+		credits []models.Credit
+	}{
+		{models.Movie{Title: "Alien", Year: 1979, Rating: 8.1, Format: models.FormatBluray}, []models.Genre{horror, scifi}, []models.Credit{scott, weaver}},
+		{models.Movie{Title: "Gladiator", Year: 2000, Rating: 8.2, Format: models.FormatBluray}, nil, []models.Credit{scott}},
+		{models.Movie{Title: "Black Hawk Down", Year: 2001, Rating: 7.7, Format: models.FormatDVD}, nil, []models.Credit{scott}},
+		{models.Movie{Title: "Thelma & Louise", Year: 1991, Rating: 7.5, Format: models.FormatBluray}, nil, []models.Credit{scott}},
+		{models.Movie{Title: "Scream", Year: 1996, Rating: 7.4, Format: models.FormatBluray}, []models.Genre{horror}, nil},
+		{models.Movie{Title: "Ghostbusters", Year: 1984, Rating: 7.3, Format: models.FormatDVD}, nil, []models.Credit{weaver}},
+	} {
+		w := serveJSON(router, "POST", "/movies", token, seed.movie)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var created models.Movie
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		require.NoError(t, metadataRepo.ReplaceMovieMetadata(created.ID, 0, seed.genres, seed.credits))
+	}
+
+	search := func(q string) []string {
+		w := serveJSON(router, "GET", "/movies/search?"+url.Values{"q": {q}}.Encode(), token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		return movieTitles(t, w)
+	}
+
+	assert.Equal(t, []string{"Scream", "Thelma & Louise"}, search("year:1990..1999"))
+	assert.Equal(t, []string{"Alien", "Black Hawk Down", "Gladiator"}, search("rating:>7.5"))
+	assert.Equal(t, []string{"Gladiator"}, search("rating:>=8 year:>=2000"))
+	assert.Equal(t, []string{"Alien", "Gladiator", "Scream", "Thelma & Louise"}, search("format:bluray"))
+	assert.Equal(t, []string{"Alien", "Black Hawk Down", "Gladiator", "Thelma & Louise"}, search(`director:"Ridley Scott"`))
+	assert.Equal(t, []string{"Gladiator", "Thelma & Louise"}, search(`director:"ridley scott" format:bluray -horror`))
+	assert.Equal(t, []string{"Alien", "Scream"}, search("genre:horror"))
+	assert.Equal(t, []string{"Alien", "Ghostbusters"}, search("actor:weaver"))
+	assert.Equal(t, []string{"Black Hawk Down", "Ghostbusters", "Gladiator", "Thelma & Louise"}, search("-horror"))
+	assert.Equal(t, []string{"Ghostbusters"}, search("ghost year:1984"))
+	assert.Equal(t, []string{"Thelma & Louise"}, search("Thelma & Louise"))
+
+	t.Run("Injection Attempts Stay Parameters", func(t *testing.T) {
+		assert.Empty(t, search(`title:"'; DROP TABLE movies; --"`))
+		assert.Len(t, search("year:1900.."), 6)
+	})
+
+	t.Run("Syntax Error", func(t *testing.T) {
+		w := serveJSON(router, "GET", "/movies/search?"+url.Values{"q": {"alien year:19x9"}}.Encode(), token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var response models.QueryErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 11, response.Position)
+		assert.Contains(t, response.Error, "19x9")
+	})
+
+	t.Run("Fuzzy With Conditions", func(t *testing.T) {
+		w := serveJSON(router, "GET", "/movies/search?"+url.Values{"q": {"Gladiatr year:2000"}, "fuzzy": {"true"}}.Encode(), token, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"Gladiator"}, movieTitles(t, w))
+
+		w = serveJSON(router, "GET", "/movies/search?"+url.Values{"q": {"year:2000"}, "fuzzy": {"true"}}.Encode(), token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
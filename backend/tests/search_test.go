@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/db"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/repositories"
+	"github.com/MichaelKlank/movie-collector/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchConfig(t *testing.T) {
+	defer os.Unsetenv("SEARCH_LANGUAGE")
+
+	os.Unsetenv("SEARCH_LANGUAGE")
+	assert.Equal(t, db.DefaultSearchConfig, db.SearchConfig())
+
+	os.Setenv("SEARCH_LANGUAGE", "English")
+	assert.Equal(t, "english", db.SearchConfig())
+
+	// Der Name wird in DDL eingesetzt und darf keine Sonderzeichen enthalten
+	os.Setenv("SEARCH_LANGUAGE", "german'); DROP TABLE movies; --")
+	assert.Equal(t, db.DefaultSearchConfig, db.SearchConfig())
+}
+
+func TestSearchFallsBackToLikeOnSQLite(t *testing.T) {
+	database := testutil.SetupTestDB(t)
+
+	require.NoError(t, db.EnsureFullTextSearch(database, "german"))
+	assert.False(t, database.Migrator().HasColumn(&models.Movie{}, "search_vector"))
+
+	repo := repositories.NewMovieRepository(database)
+	repo.EnableFullTextSearch("german")
+	for _, movie := range []models.Movie{
+		{Title: "Der Krieg der Welten", Year: 2005},
+		{Title: "Alien", Year: 1979, Overview: "Ein außerirdisches Wesen an Bord"},
+	} {
+		movie := movie
+		require.NoError(t, repo.Create(&movie))
+	}
+
+	movies, info, err := repo.SearchMovies(0, models.SearchQuery{Terms: []models.QueryTerm{{Text: "krieg"}}}, models.MovieFilter{}, models.PageRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), info.Total)
+	assert.Equal(t, "Der Krieg der Welten", movies[0].Title)
+
+	movies, _, err = repo.SearchMovies(0, models.SearchQuery{Terms: []models.QueryTerm{{Text: "wesen"}}}, models.MovieFilter{}, models.PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, movies, 1)
+	assert.Equal(t, "Alien", movies[0].Title)
+}
+
+func TestFoldText(t *testing.T) {
+	assert.Equal(t, "amelie", models.FoldText("Amélie"))
+	assert.Equal(t, "die fabelhafte welt der amelie", models.FoldText("Die fabelhafte Welt der Amélie"))
+	assert.Equal(t, "strasse", models.FoldText("Straße"))
+	assert.Equal(t, "smorrebrod", models.FoldText("Smørrebrød"))
+	assert.Equal(t, "leon: der profi", models.FoldText("Léon: Der Profi"))
+}
+
+func TestFuzzySearch(t *testing.T) {
+	database := testutil.SetupTestDB(t)
+	router := testutil.SetupRouter(database)
+	_, token := testutil.CreateTestUser(t, database, "collector", auth.RoleEditor)
+
+	for _, movie := range []models.Movie{
+		{Title: "The Terminator", Year: 1984},
+		{Title: "Terminator 2: Judgment Day", Year: 1991},
+		{Title: "Die fabelhafte Welt der Amélie", Year: 2001},
+		{Title: "Alien", Year: 1979},
+	} {
+		w := serveJSON(router, "POST", "/movies", token, movie)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	search := func(params url.Values) []models.ScoredMovie {
+		w := serveJSON(router, "GET", "/movies/search?"+params.Encode(), token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response models.FuzzySearchResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	t.Run("Accent Folding Without Fuzzy", func(t *testing.T) {
+		hits := search(url.Values{"q": {"Amelie"}})
+		require.Len(t, hits, 1)
+		assert.Equal(t, "Die fabelhafte Welt der Amélie", hits[0].Title)
+	})
+
+	t.Run("Typo Without Fuzzy Finds Nothing", func(t *testing.T) {
+		assert.Empty(t, search(url.Values{"q": {"Termintor"}}))
+	})
+
+	t.Run("Typo With Fuzzy", func(t *testing.T) {
+		hits := search(url.Values{"q": {"Termintor"}, "fuzzy": {"true"}})
+		require.Len(t, hits, 2)
+		for _, hit := range hits {
+			assert.Contains(t, hit.Title, "Terminator")
+			assert.InDelta(t, 0.9, hit.Score, 0.001)
+		}
+	})
+
+	t.Run("Exact Match Scores Highest", func(t *testing.T) {
+		hits := search(url.Values{"q": {"amelie"}, "fuzzy": {"true"}})
+		require.Len(t, hits, 1)
+		assert.Equal(t, 1.0, hits[0].Score)
+	})
+
+	t.Run("Invalid Requests", func(t *testing.T) {
+		w := serveJSON(router, "GET", "/movies/search?fuzzy=true", token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = serveJSON(router, "GET", "/movies/search?q=alien&fuzzy=maybe", token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Search Title Survives Metadata Sync", func(t *testing.T) {
+		var movie models.Movie
+		require.NoError(t, database.Where("title = ?", "Alien").First(&movie).Error)
+		require.NoError(t, repositories.NewMetadataRepository(database).ReplaceMovieMetadata(movie.ID, 117, []models.Genre{{TMDBId: 27, Name: "Horror"}}, nil))
+
+		require.NoError(t, database.First(&movie, movie.ID).Error)
+		assert.Equal(t, "alien", movie.SearchTitle)
+	})
+}
+
+func TestBackfillSearchTitles(t *testing.T) {
+	database := testutil.SetupTestDB(t)
+	require.NoError(t, database.Exec("INSERT INTO movies (title, year, status) VALUES (?, ?, ?)", "Léon", 1994, models.StatusOwned).Error)
+
+	require.NoError(t, repositories.NewMovieRepository(database).BackfillSearchTitles())
+
+	var movie models.Movie
+	require.NoError(t, database.First(&movie).Error)
+	assert.Equal(t, "leon", movie.SearchTitle)
+}
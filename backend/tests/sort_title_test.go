@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/repositories"
+	"github.com/MichaelKlank/movie-collector/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriveSortTitle(t *testing.T) {
+	assert.Equal(t, "Matrix", models.DeriveSortTitle("The Matrix"))
+	assert.Equal(t, "Untergang", models.DeriveSortTitle("Der Untergang"))
+	assert.Equal(t, "Beautiful Mind", models.DeriveSortTitle("A Beautiful Mind"))
+	assert.Equal(t, "Theodore Rex", models.DeriveSortTitle("Theodore Rex"))
+	assert.Equal(t, "Das", models.DeriveSortTitle("Das"))
+	assert.Equal(t, "L'Odyssée", models.DeriveSortTitle("L'Odyssée"))
+
+	require.NoError(t, models.SetSortTitleLanguages([]string{"fr"}))
+	t.Cleanup(func() {
+		require.NoError(t, models.SetSortTitleLanguages([]string{"de", "en"}))
+	})
+	assert.Equal(t, "Odyssée", models.DeriveSortTitle("L'Odyssée"))
+	assert.Equal(t, "Misérables", models.DeriveSortTitle("Les Misérables"))
+	assert.Equal(t, "The Matrix", models.DeriveSortTitle("The Matrix"))
+
+	assert.Error(t, models.SetSortTitleLanguages([]string{"xx"}))
+	assert.Equal(t, "Odyssée", models.DeriveSortTitle("L'Odyssée"))
+}
+
+func TestSortTitle(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	router := testutil.SetupRouter(db)
+	_, token := testutil.CreateTestUser(t, db, "collector", auth.RoleEditor)
+
+	create := func(movie models.Movie) models.Movie {
+		w := serveJSON(router, "POST", "/movies", token, movie)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var created models.Movie
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		return created
+	}
+	update := func(movie models.Movie) models.Movie {
+		w := serveJSON(router, "PUT", fmt.Sprintf("/movies/%d", movie.ID), token, movie)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var updated models.Movie
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+		return updated
+	}
+
+	matrix := create(models.Movie{Title: "The Matrix", Year: 1999})
+	assert.Equal(t, "Matrix", matrix.SortTitle)
+	assert.False(t, matrix.SortTitleCustom)
+	create(models.Movie{Title: "Der Untergang", Year: 2004})
+	create(models.Movie{Title: "Alien", Year: 1979})
+	create(models.Movie{Title: "Zodiac", Year: 2007})
+	episode := create(models.Movie{Title: "Krieg der Sterne", SortTitle: "Star Wars 4", Year: 1977})
+	assert.Equal(t, "Star Wars 4", episode.SortTitle)
+	assert.True(t, episode.SortTitleCustom)
+
+	list := func(query string) []string {
+		w := serveJSON(router, "GET", "/movies"+query, token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		return movieTitles(t, w)
+	}
+	assert.Equal(t, []string{"Alien", "The Matrix", "Krieg der Sterne", "Der Untergang", "Zodiac"}, list(""))
+	assert.Equal(t, []string{"Zodiac", "Der Untergang", "Krieg der Sterne", "The Matrix", "Alien"}, list("?order=desc"))
+	assert.Equal(t, []string{"Krieg der Sterne"}, list("?starts_with=S"))
+
+	t.Run("Title Change Follows Derived Sort Title", func(t *testing.T) {
+		matrix.Title = "The Matrix Reloaded"
+		matrix = update(matrix)
+		assert.Equal(t, "Matrix Reloaded", matrix.SortTitle)
+		assert.False(t, matrix.SortTitleCustom)
+	})
+
+	t.Run("Custom Sort Title Is Kept", func(t *testing.T) {
+		episode.Title = "Star Wars: Episode IV"
+		episode = update(episode)
+		assert.Equal(t, "Star Wars 4", episode.SortTitle)
+		assert.True(t, episode.SortTitleCustom)
+
+		episode.SortTitle = ""
+		episode = update(episode)
+		assert.Equal(t, "Star Wars: Episode IV", episode.SortTitle)
+		assert.False(t, episode.SortTitleCustom)
+	})
+
+	t.Run("Backfill", func(t *testing.T) {
+		require.NoError(t, db.Model(&models.Movie{}).Where("title = ?", "Zodiac").
+			UpdateColumns(map[string]interface{}{"sort_title": "", "sort_key": ""}).Error)
+		require.NoError(t, repositories.NewMovieRepository(db).BackfillSortTitles())
+
+		var zodiac models.Movie
+		require.NoError(t, db.Where("title = ?", "Zodiac").First(&zodiac).Error)
+		assert.Equal(t, "Zodiac", zodiac.SortTitle)
+		assert.Equal(t, "zodiac", zodiac.SortKey)
+	})
+}
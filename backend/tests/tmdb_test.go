@@ -1,14 +1,28 @@
 package tests
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/handlers"
+	"github.com/MichaelKlank/movie-collector/backend/services"
+	"github.com/MichaelKlank/movie-collector/backend/testutil"
 	"github.com/MichaelKlank/movie-collector/backend/tmdb"
+	"github.com/gin-contrib/cache/persistence"
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type MockTMDBClient struct {
@@ -156,10 +170,50 @@ func TestGetMovieDetails(t *testing.T) {
 
 func TestCache(t *testing.T) {
 	cache := tmdb.NewCache()
-	cache.Set("test-key")
-	assert.True(t, cache.Get("test-key"))
+	cache.Set("test-key", "value")
+	value, ok := cache.Get("test-key")
+	assert.True(t, ok)
+	assert.Equal(t, "value", value)
 	cache.Delete("test-key")
-	assert.False(t, cache.Get("test-key"))
+	_, ok = cache.Get("test-key")
+	assert.False(t, ok)
+
+	stats := cache.Stats()
+	assert.Equal(t, int64(1), stats.Hits)
+	assert.Equal(t, int64(1), stats.Misses)
+	assert.Equal(t, 0, stats.Entries)
+}
+
+func TestCacheExpiry(t *testing.T) {
+	cache := tmdb.NewCacheWithLimits(10, 20*time.Millisecond)
+	cache.Set("short", 1)
+	cache.SetWithTTL("long", 2, time.Hour)
+
+	time.Sleep(40 * time.Millisecond)
+	_, ok := cache.Get("short")
+	assert.False(t, ok, "abgelaufener Eintrag darf nicht geliefert werden")
+	value, ok := cache.Get("long")
+	assert.True(t, ok)
+	assert.Equal(t, 2, value)
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestCacheEviction(t *testing.T) {
+	cache := tmdb.NewCacheWithLimits(2, time.Hour)
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	// Zugriff macht "a" zum zuletzt genutzten Eintrag, verdrängt wird "b"
+	_, _ = cache.Get("a")
+	cache.Set("c", 3)
+
+	_, ok := cache.Get("b")
+	assert.False(t, ok)
+	_, ok = cache.Get("a")
+	assert.True(t, ok)
+	_, ok = cache.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 2, cache.Len())
+	assert.Equal(t, int64(1), cache.Stats().Evictions)
 }
 
 func TestCacheConcurrency(t *testing.T) {
@@ -171,7 +225,7 @@ func TestCacheConcurrency(t *testing.T) {
 	for i := 0; i < numGoroutines; i++ {
 		go func(index int) {
 			key := fmt.Sprintf("key-%d", index)
-			cache.Set(key)
+			cache.Set(key, index)
 			done <- true
 		}(i)
 	}
@@ -184,10 +238,95 @@ func TestCacheConcurrency(t *testing.T) {
 	// Überprüfe, ob alle Schlüssel geschrieben wurden
 	for i := 0; i < numGoroutines; i++ {
 		key := fmt.Sprintf("key-%d", i)
-		assert.True(t, cache.Get(key), "Schlüssel %s wurde nicht im Cache gefunden", key)
+		value, ok := cache.Get(key)
+		assert.True(t, ok, "Schlüssel %s wurde nicht im Cache gefunden", key)
+		assert.Equal(t, i, value)
 	}
 }
 
+func TestClientCachesResponses(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"results": []tmdb.Movie{{ID: 1, Title: r.URL.Query().Get("query"), Overview: "Im Weltraum hört dich niemand schreien."}},
+		})
+	}))
+	defer server.Close()
+
+	t.Run("Memory", func(t *testing.T) {
+		requests.Store(0)
+		client := tmdb.NewClientWithBaseURL(server.URL)
+		for i := 0; i < 3; i++ {
+			movies, err := client.SearchMovies("Alien")
+			require.NoError(t, err)
+			require.Len(t, movies, 1)
+			assert.Equal(t, "Alien", movies[0].Title)
+		}
+		_, err := client.SearchMovies("Aliens")
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), requests.Load())
+		stats := client.Stats()
+		assert.Equal(t, int64(2), stats.Cache.Hits)
+		assert.Equal(t, int64(2), stats.Cache.Misses)
+		assert.Equal(t, 2, stats.Cache.Entries)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		requests.Store(0)
+		client := tmdb.NewClientWithBaseURL(server.URL)
+		client.CacheTTL = 10 * time.Millisecond
+		_, err := client.SearchMovies("Alien")
+		require.NoError(t, err)
+		time.Sleep(30 * time.Millisecond)
+		_, err = client.SearchMovies("Alien")
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("Shared Store", func(t *testing.T) {
+		requests.Store(0)
+		store := persistence.NewInMemoryStore(time.Minute)
+		first := tmdb.NewClientWithBaseURL(server.URL)
+		first.UseStore(store)
+		_, err := first.SearchMovies("Alien")
+		require.NoError(t, err)
+
+		// Ein zweiter Client, z. B. nach einem Neustart, liest die Antwort aus dem Speicher
+		second := tmdb.NewClientWithBaseURL(server.URL)
+		second.UseStore(store)
+		movies, err := second.SearchMovies("Alien")
+		require.NoError(t, err)
+		require.Len(t, movies, 1)
+		assert.Equal(t, "Alien", movies[0].Title)
+
+		assert.Equal(t, int32(1), requests.Load())
+		assert.Equal(t, int64(1), second.Stats().StoreHits)
+	})
+
+	t.Run("Errors Are Not Cached", func(t *testing.T) {
+		var calls atomic.Int32
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id": 7, "title": "Se7en"}`))
+		}))
+		defer failing.Close()
+
+		client := tmdb.NewClientWithBaseURL(failing.URL)
+		_, err := client.GetMovieDetails(7)
+		assert.ErrorIs(t, err, tmdb.ErrNotFound)
+		movie, err := client.GetMovieDetails(7)
+		require.NoError(t, err)
+		assert.Equal(t, "Se7en", movie.Title)
+	})
+}
+
 func TestSearchMoviesError(t *testing.T) {
 	// Test für ungültige Server-Antwort
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -261,3 +400,398 @@ func TestTestConnection(t *testing.T) {
 	err = client.TestConnection()
 	assert.Error(t, err)
 }
+
+func TestClientErrorKinds(t *testing.T) {
+	for _, tc := range []struct {
+		status   int
+		expected error
+	}{
+		{http.StatusUnauthorized, tmdb.ErrUnauthorized},
+		{http.StatusNotFound, tmdb.ErrNotFound},
+		{http.StatusTooManyRequests, tmdb.ErrRateLimited},
+		{http.StatusServiceUnavailable, tmdb.ErrUnavailable},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tc.status)
+			_, _ = w.Write([]byte(`{"status_code": 7, "status_message": "Invalid API key: You must be granted a valid key."}`))
+		}))
+
+		client := tmdb.NewClientWithBaseURL(server.URL)
+		client.RetryDelay = time.Millisecond
+		_, err := client.GetMovieDetails(603)
+		assert.ErrorIs(t, err, tc.expected, "Status %d", tc.status)
+
+		var apiErr *tmdb.APIError
+		if assert.ErrorAs(t, err, &apiErr) {
+			assert.Equal(t, tc.status, apiErr.StatusCode)
+			assert.Contains(t, apiErr.Message, "Invalid API key")
+		}
+		server.Close()
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	t.Run("Transient Errors Are Retried", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			_, _ = w.Write([]byte(`{"results": [{"id": 603, "title": "Matrix", "original_title": "The Matrix", "overview": "Ein Hacker erfährt die Wahrheit."}]}`))
+		}))
+		defer server.Close()
+
+		client := tmdb.NewClientWithBaseURL(server.URL)
+		client.RetryDelay = time.Millisecond
+		movies, err := client.SearchMovies("matrix")
+		require.NoError(t, err)
+		assert.Len(t, movies, 1)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("Retry-After Is Honoured", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			_, _ = w.Write([]byte(`{"results": []}`))
+		}))
+		defer server.Close()
+
+		client := tmdb.NewClientWithBaseURL(server.URL)
+		client.RetryDelay = time.Millisecond
+		start := time.Now()
+		_, err := client.SearchMovies("matrix")
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), time.Second)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("Long Retry-After Is Reported", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		_, err := tmdb.NewClientWithBaseURL(server.URL).SearchMovies("matrix")
+		assert.ErrorIs(t, err, tmdb.ErrRateLimited)
+		assert.Equal(t, 2*time.Minute, tmdb.RetryAfter(err))
+	})
+
+	t.Run("Client Errors Are Not Retried", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		_, err := tmdb.NewClientWithBaseURL(server.URL).GetMovieDetails(1)
+		assert.ErrorIs(t, err, tmdb.ErrNotFound)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("Context Deadline", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+		}))
+		defer server.Close()
+
+		os.Setenv("TMDB_API_KEY", "geheimer-schluessel")
+		client := tmdb.NewClientWithBaseURL(server.URL)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := client.SearchMoviesContext(ctx, "matrix")
+		assert.ErrorIs(t, err, tmdb.ErrUnavailable)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.NotContains(t, err.Error(), "geheimer-schluessel")
+	})
+}
+
+func TestRespondTMDBError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	for _, tc := range []struct {
+		err    error
+		status int
+	}{
+		{&tmdb.APIError{StatusCode: http.StatusNotFound}, http.StatusNotFound},
+		{&tmdb.APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 1500 * time.Millisecond}, http.StatusTooManyRequests},
+		{&tmdb.APIError{StatusCode: http.StatusUnauthorized}, http.StatusBadGateway},
+		{&tmdb.APIError{StatusCode: http.StatusInternalServerError}, http.StatusServiceUnavailable},
+		{fmt.Errorf("%w: %w", services.ErrTMDBRequest, tmdb.ErrNotFound), http.StatusNotFound},
+		{errors.New("ungültige TMDB-Antwort"), http.StatusBadGateway},
+	} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		handlers.RespondTMDBError(c, tc.err)
+		assert.Equal(t, tc.status, w.Code, tc.err.Error())
+		if tc.status == http.StatusTooManyRequests {
+			assert.Equal(t, "2", w.Header().Get("Retry-After"))
+		}
+	}
+}
+
+func TestClientCoalescesIdenticalRequests(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		_, _ = w.Write([]byte(`{"results": [{"id": 603, "title": "Matrix", "original_title": "The Matrix", "overview": "Ein Hacker erfährt die Wahrheit."}]}`))
+	}))
+	defer server.Close()
+
+	client := tmdb.NewClientWithBaseURL(server.URL)
+	const callers = 10
+	var wg sync.WaitGroup
+	results := make(chan []tmdb.Movie, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			movies, err := client.SearchMovies("matrix")
+			assert.NoError(t, err)
+			results <- movies
+		}()
+	}
+	// Warten, bis alle Aufrufe an der laufenden Anfrage hängen
+	require.Eventually(t, func() bool {
+		return client.Stats().Calls == callers
+	}, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	for movies := range results {
+		require.Len(t, movies, 1)
+		assert.Equal(t, "Matrix", movies[0].Title)
+	}
+	stats := client.Stats()
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, int64(1), stats.Requests)
+	assert.Equal(t, int64(callers-1), stats.Coalesced)
+}
+
+func TestClientRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results": []}`))
+	}))
+	defer server.Close()
+
+	client := tmdb.NewClientWithBaseURL(server.URL)
+	client.SetRateLimit(20, 1)
+	start := time.Now()
+	for _, query := range []string{"alien", "heat", "dune"} {
+		_, err := client.SearchMovies(query)
+		require.NoError(t, err)
+	}
+	// Nach dem ersten Token kommt alle 50 ms ein weiteres hinzu
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+	stats := client.Stats()
+	assert.Equal(t, int64(3), stats.Requests)
+	assert.Equal(t, int64(2), stats.Throttled)
+}
+
+func TestClientSetRateLimitWhileRequesting(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results": []}`))
+	}))
+	defer server.Close()
+
+	// Mit -race meldet dieser Test einen ungeschützten Austausch des Limiters
+	client := tmdb.NewClientWithBaseURL(server.URL)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			_, err := client.SearchMovies(fmt.Sprintf("film %d", i))
+			assert.NoError(t, err)
+		}(i)
+		go func() {
+			defer wg.Done()
+			client.SetRateLimit(1000, 10)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestTMDBStatsRoute(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	router := testutil.SetupRouter(db)
+	_, adminToken := testutil.CreateTestUser(t, db, "admin", auth.RoleAdmin)
+	_, editorToken := testutil.CreateTestUser(t, db, "editor", auth.RoleEditor)
+
+	w := serveJSON(router, "GET", "/tmdb/stats", editorToken, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = serveJSON(router, "GET", "/tmdb/stats", adminToken, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var stats map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	for _, key := range []string{"calls", "coalesced", "requests", "retries", "throttled", "rate_limited", "errors", "store_hits", "cache"} {
+		assert.Contains(t, stats, key)
+	}
+	var cacheStats tmdb.CacheStats
+	require.NoError(t, json.Unmarshal(stats["cache"], &cacheStats))
+}
+
+func TestClientLocalization(t *testing.T) {
+	var (
+		mutex    sync.Mutex
+		requests []url.Values
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		mutex.Lock()
+		requests = append(requests, query)
+		mutex.Unlock()
+
+		// Auf Deutsch fehlt die Beschreibung von "Heat"
+		english := query.Get("language") == tmdb.FallbackLanguage
+		heat := tmdb.Movie{ID: 949, Title: "Heat", OriginalTitle: "Heat"}
+		matrix := tmdb.Movie{ID: 603, Title: "Matrix", OriginalTitle: "The Matrix", Overview: "Ein Hacker erfährt die Wahrheit."}
+		if english {
+			heat.Overview = "A group of high-end professional thieves."
+			matrix.Title = "The Matrix"
+			matrix.Overview = "A hacker learns the truth."
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/search/movie":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": []tmdb.Movie{matrix, heat}})
+		case "/movie/949":
+			_ = json.NewEncoder(w).Encode(heat)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	lastRequest := func() url.Values {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return requests[len(requests)-1]
+	}
+	reset := func() {
+		mutex.Lock()
+		defer mutex.Unlock()
+		requests = nil
+	}
+
+	t.Run("Default Language With English Fallback", func(t *testing.T) {
+		reset()
+		client := tmdb.NewClientWithBaseURL(server.URL)
+		movies, err := client.SearchMovies("heat")
+		require.NoError(t, err)
+		require.Len(t, movies, 2)
+		assert.Equal(t, "Matrix", movies[0].Title)
+		assert.Equal(t, "The Matrix", movies[0].OriginalTitle)
+		assert.Equal(t, "Ein Hacker erfährt die Wahrheit.", movies[0].Overview)
+		assert.Equal(t, "A group of high-end professional thieves.", movies[1].Overview)
+
+		require.Len(t, requests, 2)
+		assert.Equal(t, tmdb.DefaultLanguage, requests[0].Get("language"))
+		assert.Equal(t, tmdb.FallbackLanguage, requests[1].Get("language"))
+
+		// Beide Antworten kommen beim nächsten Aufruf aus dem Cache
+		movies, err = client.SearchMovies("heat")
+		require.NoError(t, err)
+		assert.Equal(t, "A group of high-end professional thieves.", movies[1].Overview)
+		assert.Len(t, requests, 2)
+	})
+
+	t.Run("Region And Override", func(t *testing.T) {
+		reset()
+		client := tmdb.NewClientWithBaseURL(server.URL)
+		client.Locale = tmdb.Locale{Language: "fr-FR", Region: "FR"}
+		_, err := client.SearchMovies("matrix")
+		require.NoError(t, err)
+		assert.Equal(t, "fr-FR", requests[0].Get("language"))
+		assert.Equal(t, "FR", requests[0].Get("region"))
+
+		movies, err := client.SearchMoviesLocalized(context.Background(), "matrix", tmdb.Locale{Language: "en-GB"})
+		require.NoError(t, err)
+		assert.Equal(t, "en-GB", lastRequest().Get("language"))
+		assert.Empty(t, lastRequest().Get("region"))
+		// Englische Treffer ohne Beschreibung lösen keine weitere Anfrage aus
+		assert.Empty(t, movies[1].Overview)
+	})
+
+	t.Run("Details Fallback", func(t *testing.T) {
+		reset()
+		movie, err := tmdb.NewClientWithBaseURL(server.URL).GetMovieDetails(949)
+		require.NoError(t, err)
+		assert.Equal(t, "A group of high-end professional thieves.", movie.Overview)
+		require.Len(t, requests, 2)
+		assert.Equal(t, "credits", requests[1].Get("append_to_response"))
+	})
+
+	t.Run("Locale From Environment", func(t *testing.T) {
+		t.Setenv("TMDB_LANGUAGE", "")
+		t.Setenv("TMDB_REGION", "")
+		locale, err := tmdb.LocaleFromEnv()
+		require.NoError(t, err)
+		assert.Equal(t, tmdb.Locale{Language: tmdb.DefaultLanguage}, locale)
+
+		t.Setenv("TMDB_LANGUAGE", "en-US")
+		t.Setenv("TMDB_REGION", "US")
+		locale, err = tmdb.LocaleFromEnv()
+		require.NoError(t, err)
+		assert.Equal(t, tmdb.Locale{Language: "en-US", Region: "US"}, locale)
+
+		t.Setenv("TMDB_LANGUAGE", "deutsch")
+		locale, err = tmdb.LocaleFromEnv()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), tmdb.DefaultLanguage)
+		assert.Equal(t, tmdb.Locale{Language: tmdb.DefaultLanguage, Region: "US"}, locale)
+
+		// Eine ungültige Region lässt die gültige Sprache bestehen
+		t.Setenv("TMDB_LANGUAGE", "en-GB")
+		t.Setenv("TMDB_REGION", "Deutschland")
+		locale, err = tmdb.LocaleFromEnv()
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), tmdb.DefaultLanguage)
+		assert.Equal(t, tmdb.Locale{Language: "en-GB"}, locale)
+	})
+}
+
+func TestTMDBLocaleParameters(t *testing.T) {
+	var languages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		languages = append(languages, r.URL.Query().Get("language"))
+		_, _ = w.Write([]byte(`{"results": [{"id": 603, "title": "Matrix", "overview": "Ein Hacker erfährt die Wahrheit."}]}`))
+	}))
+	defer server.Close()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := handlers.NewTMDBHandler(tmdb.NewClientWithBaseURL(server.URL))
+	router.GET("/tmdb/search", handler.SearchMovies)
+
+	for _, tc := range []struct {
+		query    string
+		status   int
+		language string
+	}{
+		{"query=matrix", http.StatusOK, tmdb.DefaultLanguage},
+		{"query=matrix&language=it-IT&region=IT", http.StatusOK, "it-IT"},
+		{"query=matrix&language=german", http.StatusBadRequest, ""},
+		{"query=matrix&region=deu", http.StatusBadRequest, ""},
+	} {
+		languages = nil
+		w := serveJSON(router, "GET", "/tmdb/search?"+tc.query, "", nil)
+		assert.Equal(t, tc.status, w.Code, tc.query)
+		if tc.status == http.StatusOK {
+			assert.Equal(t, []string{tc.language}, languages, tc.query)
+		} else {
+			assert.Empty(t, languages, tc.query)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/handlers"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/repositories"
+	"github.com/MichaelKlank/movie-collector/backend/services"
+	"github.com/MichaelKlank/movie-collector/backend/testutil"
+	"github.com/MichaelKlank/movie-collector/backend/tmdb"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const breakingBadDetails = `{
+	"id": 1396,
+	"name": "Breaking Bad",
+	"original_name": "Breaking Bad",
+	"overview": "Ein Chemielehrer wird zum Drogenkoch.",
+	"first_air_date": "2008-01-20",
+	"number_of_seasons": 5,
+	"number_of_episodes": 62,
+	"episode_run_time": [47],
+	"genres": [{"id": 18, "name": "Drama"}],
+	"seasons": [
+		{"id": 3577, "name": "Specials", "season_number": 0, "episode_count": 9},
+		{"id": 3572, "name": "Staffel 1", "season_number": 1, "episode_count": 7},
+		{"id": 3573, "name": "Staffel 2", "season_number": 2, "episode_count": 13},
+		{"id": 3575, "name": "Staffel 3", "season_number": 3, "episode_count": 13},
+		{"id": 3576, "name": "Staffel 4", "season_number": 4, "episode_count": 13},
+		{"id": 6456, "name": "Staffel 5", "season_number": 5, "episode_count": 16}
+	],
+	"credits": {
+		"cast": [{"id": 17419, "name": "Bryan Cranston", "character": "Walter White", "order": 0}],
+		"crew": []
+	}
+}`
+
+func tvServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/search/multi":
+			_, _ = w.Write([]byte(`{"results": [
+				{"id": 1396, "media_type": "tv", "name": "Breaking Bad", "original_name": "Breaking Bad", "first_air_date": "2008-01-20", "overview": "Serie"},
+				{"id": 17419, "media_type": "person", "name": "Bryan Cranston"},
+				{"id": 559969, "media_type": "movie", "title": "El Camino", "release_date": "2019-10-11", "overview": "Film"}
+			]}`))
+		case "/search/tv":
+			_, _ = w.Write([]byte(`{"results": [{"id": 1396, "name": "Breaking Bad", "first_air_date": "2008-01-20", "overview": "Serie"}]}`))
+		case "/tv/1396":
+			assert.Equal(t, "credits", r.URL.Query().Get("append_to_response"))
+			_, _ = w.Write([]byte(breakingBadDetails))
+		case "/tv/1396/season/1":
+			_, _ = w.Write([]byte(`{"id": 3572, "name": "Staffel 1", "overview": "Walter erfährt von seiner Krankheit.", "season_number": 1, "episodes": [
+				{"id": 62085, "name": "Der Einstieg", "season_number": 1, "episode_number": 1, "runtime": 58}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestTVClient(t *testing.T) {
+	client := tmdb.NewClientWithBaseURL(tvServer(t).URL)
+	ctx := context.Background()
+
+	t.Run("Multi Search", func(t *testing.T) {
+		results, err := client.SearchMultiContext(ctx, "breaking bad")
+		require.NoError(t, err)
+		require.Len(t, results, 2, "Personen werden verworfen")
+		assert.Equal(t, tmdb.MediaTypeTV, results[0].MediaType)
+		assert.Equal(t, "Breaking Bad", results[0].Title)
+		assert.Equal(t, "2008-01-20", results[0].ReleaseDate)
+		assert.Equal(t, tmdb.MediaTypeMovie, results[1].MediaType)
+		assert.Equal(t, "El Camino", results[1].Title)
+	})
+
+	t.Run("TV Search", func(t *testing.T) {
+		results, err := client.SearchTVContext(ctx, "breaking bad")
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, tmdb.MediaTypeTV, results[0].MediaType)
+		assert.Equal(t, "Breaking Bad", results[0].Title)
+	})
+
+	t.Run("Details And Seasons", func(t *testing.T) {
+		show, err := client.GetTVDetailsContext(ctx, 1396)
+		require.NoError(t, err)
+		assert.Equal(t, 5, show.NumberOfSeasons)
+		assert.Equal(t, 47, show.EpisodeRuntime())
+		assert.Equal(t, 62, show.EpisodeCount(0, 0))
+		assert.Equal(t, 20, show.EpisodeCount(1, 2))
+		assert.Equal(t, 62, show.EpisodeCount(1, 5), "Specials zählen nicht mit")
+
+		season, err := client.GetSeasonDetailsContext(ctx, 1396, 1)
+		require.NoError(t, err)
+		require.Len(t, season.Episodes, 1)
+		assert.Equal(t, "Der Einstieg", season.Episodes[0].Name)
+
+		_, err = client.GetSeasonDetailsContext(ctx, 1396, 9)
+		assert.ErrorIs(t, err, tmdb.ErrNotFound)
+	})
+}
+
+func TestTMDBSearchTypes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := handlers.NewTMDBHandler(tmdb.NewClientWithBaseURL(tvServer(t).URL))
+	router.GET("/tmdb/search", handler.SearchMovies)
+	router.GET("/tmdb/tv/:id", handler.GetTVShow)
+	router.GET("/tmdb/tv/:id/season/:season", handler.GetSeason)
+
+	w := serveJSON(router, "GET", "/tmdb/search?query=breaking&type=multi", "", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var results []map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &results))
+	require.Len(t, results, 2)
+	assert.Equal(t, "tv", results[0]["media_type"])
+	assert.Equal(t, "movie", results[1]["media_type"])
+
+	w = serveJSON(router, "GET", "/tmdb/search?query=breaking&type=person", "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = serveJSON(router, "GET", "/tmdb/tv/1396", "", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var show tmdb.TVShow
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &show))
+	assert.Len(t, show.Seasons, 6)
+
+	w = serveJSON(router, "GET", "/tmdb/tv/1396/season/1", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveJSON(router, "GET", "/tmdb/tv/1396/season/-1", "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = serveJSON(router, "GET", "/tmdb/tv/abc", "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestTVBoxSets(t *testing.T) {
+	database := testutil.SetupTestDB(t)
+	router := testutil.SetupRouter(database)
+	_, token := testutil.CreateTestUser(t, database, "collector", auth.RoleEditor)
+
+	create := func(movie models.Movie) (*httptest.ResponseRecorder, models.Movie) {
+		w := serveJSON(router, "POST", "/movies", token, movie)
+		var created models.Movie
+		_ = json.Unmarshal(w.Body.Bytes(), &created)
+		return w, created
+	}
+
+	w, boxSet := create(models.Movie{Title: "Breaking Bad", Year: 2008, TMDBId: "1396", MediaType: models.MediaTypeTV, SeasonFrom: 1, SeasonTo: 5})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, 1, boxSet.SeasonFrom)
+	assert.Equal(t, 5, boxSet.SeasonTo)
+
+	t.Run("Movies Default To Movie", func(t *testing.T) {
+		w, movie := create(models.Movie{Title: "El Camino", Year: 2019, TMDBId: "559969"})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		assert.Equal(t, models.MediaTypeMovie, movie.MediaType)
+	})
+
+	t.Run("Invalid Seasons", func(t *testing.T) {
+		for _, movie := range []models.Movie{
+			{Title: "Film mit Staffeln", Year: 2000, SeasonFrom: 1, SeasonTo: 2},
+			{Title: "Nur erste Staffel", Year: 2000, MediaType: models.MediaTypeTV, SeasonFrom: 1},
+			{Title: "Rückwärts", Year: 2000, MediaType: models.MediaTypeTV, SeasonFrom: 3, SeasonTo: 2},
+		} {
+			w, _ := create(movie)
+			assert.Equal(t, http.StatusBadRequest, w.Code, movie.Title)
+		}
+	})
+
+	t.Run("Duplicates Depend On Type And Seasons", func(t *testing.T) {
+		// Ein Film mit derselben TMDB-ID ist ein anderer Titel
+		w, _ := create(models.Movie{Title: "Zufällig gleiche ID", Year: 2000, TMDBId: "1396"})
+		assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		// Eine weitere Box derselben Serie ebenso
+		w, _ = create(models.Movie{Title: "Breaking Bad", Year: 2008, TMDBId: "1396", MediaType: models.MediaTypeTV, SeasonFrom: 5, SeasonTo: 5})
+		assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		w, _ = create(models.Movie{Title: "Breaking Bad", Year: 2008, TMDBId: "1396", MediaType: models.MediaTypeTV, SeasonFrom: 1, SeasonTo: 5})
+		require.Equal(t, http.StatusConflict, w.Code)
+		var conflict struct {
+			Movie models.Movie `json:"movie"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &conflict))
+		assert.Equal(t, boxSet.ID, conflict.Movie.ID)
+	})
+
+	t.Run("Filter By Media Type", func(t *testing.T) {
+		w := serveJSON(router, "GET", "/movies?media_type=tv", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, []string{"Breaking Bad", "Breaking Bad"}, movieTitles(t, w))
+
+		w = serveJSON(router, "GET", "/movies?media_type=book", token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Update Keeps Seasons", func(t *testing.T) {
+		w := serveJSON(router, "PUT", "/movies/"+strconv.Itoa(int(boxSet.ID)), token, models.Movie{Title: "Breaking Bad (Komplettbox)", Year: 2008})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var updated models.Movie
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+		assert.Equal(t, models.MediaTypeTV, updated.MediaType)
+		assert.Equal(t, 1, updated.SeasonFrom)
+		assert.Equal(t, 5, updated.SeasonTo)
+	})
+
+	t.Run("Update Cannot Create Duplicates", func(t *testing.T) {
+		w, finale := create(models.Movie{Title: "Breaking Bad", Year: 2008, TMDBId: "1396", MediaType: models.MediaTypeTV, SeasonFrom: 6, SeasonTo: 6})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		path := "/movies/" + strconv.Itoa(int(finale.ID))
+
+		// Die fünfte Staffel hat schon eine eigene Box
+		w = serveJSON(router, "PUT", path, token, models.Movie{Title: "Breaking Bad", Year: 2008, TMDBId: "1396", MediaType: models.MediaTypeTV, SeasonFrom: 5, SeasonTo: 5})
+		require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+		var conflict struct {
+			Movie models.Movie `json:"movie"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &conflict))
+		assert.Equal(t, 5, conflict.Movie.SeasonFrom)
+		assert.NotEqual(t, finale.ID, conflict.Movie.ID)
+
+		// Die TMDB-ID eines anderen Films ebenso
+		w = serveJSON(router, "PUT", path, token, models.Movie{Title: "Zufall", Year: 2000, TMDBId: "1396", MediaType: models.MediaTypeMovie})
+		assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+
+		// Ein unveränderter Eintrag kollidiert nicht mit sich selbst
+		w = serveJSON(router, "PUT", path, token, models.Movie{Title: "Breaking Bad (Finale)", Year: 2008, TMDBId: "1396", MediaType: models.MediaTypeTV, SeasonFrom: 6, SeasonTo: 6})
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+}
+
+func TestSyncTVBoxSet(t *testing.T) {
+	database := testutil.SetupTestDB(t)
+	movieRepo := repositories.NewMovieRepository(database)
+	service := services.NewMetadataService(tmdb.NewClientWithBaseURL(tvServer(t).URL), movieRepo, repositories.NewMetadataRepository(database))
+
+	boxSet := models.Movie{Title: "Breaking Bad", Year: 2008, TMDBId: "1396", MediaType: models.MediaTypeTV, SeasonFrom: 1, SeasonTo: 2}
+	require.NoError(t, movieRepo.Create(&boxSet))
+
+	movie, err := service.SyncMovie(context.Background(), 0, boxSet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 47, movie.Runtime)
+	assert.Equal(t, 20, movie.EpisodeCount)
+	assert.Equal(t, "Breaking Bad", movie.OriginalTitle)
+	require.Len(t, movie.Genres, 1)
+	assert.Equal(t, "Drama", movie.Genres[0].Name)
+	require.Len(t, movie.Credits, 1)
+	assert.Equal(t, "Walter White", movie.Credits[0].Character)
+}
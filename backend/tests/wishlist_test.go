@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/MichaelKlank/movie-collector/backend/auth"
+	"github.com/MichaelKlank/movie-collector/backend/models"
+	"github.com/MichaelKlank/movie-collector/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWishlist(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	router := testutil.SetupRouter(db)
+	_, token := testutil.CreateTestUser(t, db, "collector", auth.RoleAdmin)
+
+	w := serveJSON(router, "POST", "/movies", token, models.Movie{Title: "Alien", Year: 1979})
+	require.Equal(t, http.StatusCreated, w.Code)
+	var owned models.Movie
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &owned))
+	assert.Equal(t, models.StatusOwned, owned.Status)
+
+	w = serveJSON(router, "POST", "/movies", token, models.Movie{
+		Title:           "Dune: Part Two",
+		Year:            2024,
+		TMDBId:          "693134",
+		Overview:        "Paul Atreides unites with Chani and the Fremen.",
+		Status:          models.StatusWishlist,
+		TargetPrice:     19.99,
+		PreferredFormat: models.FormatUHD,
+		Priority:        1,
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var wanted models.Movie
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &wanted))
+
+	w = serveJSON(router, "POST", "/movies", token, models.Movie{Title: "Furiosa", Year: 2024, Status: models.StatusPreordered})
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	t.Run("Validation", func(t *testing.T) {
+		invalid := []models.Movie{
+			{Title: "Bad Status", Year: 2000, Status: "borrowed"},
+			{Title: "Bad Priority", Year: 2000, Status: models.StatusWishlist, Priority: 9},
+			{Title: "Bad Price", Year: 2000, Status: models.StatusWishlist, TargetPrice: -5},
+			{Title: "Bad Format", Year: 2000, Status: models.StatusWishlist, PreferredFormat: "vhs"},
+		}
+		for _, movie := range invalid {
+			w := serveJSON(router, "POST", "/movies", token, movie)
+			assert.Equal(t, http.StatusBadRequest, w.Code, movie.Title)
+		}
+	})
+
+	t.Run("Main List Shows Owned Movies Only", func(t *testing.T) {
+		w := serveJSON(router, "GET", "/movies", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"Alien"}, movieTitles(t, w))
+
+		w = serveJSON(router, "GET", "/movies?status=wishlist", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"Dune: Part Two"}, movieTitles(t, w))
+
+		w = serveJSON(router, "GET", "/movies?status=all", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Len(t, movieTitles(t, w), 3)
+
+		w = serveJSON(router, "GET", "/movies?status=lost", token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Wishlist Items Cannot Be Lent", func(t *testing.T) {
+		w := serveJSON(router, "POST", fmt.Sprintf("/movies/%d/loans", wanted.ID), token, models.LoanRequest{BorrowerName: "Chani"})
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Acquire", func(t *testing.T) {
+		path := fmt.Sprintf("/movies/%d/acquire", wanted.ID)
+		w := serveJSON(router, "POST", path, token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var movie models.Movie
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &movie))
+		assert.Equal(t, models.StatusOwned, movie.Status)
+		assert.Equal(t, models.FormatUHD, movie.Format)
+		assert.Equal(t, "693134", movie.TMDBId)
+		assert.Equal(t, wanted.Overview, movie.Overview)
+		assert.Zero(t, movie.TargetPrice)
+		assert.Zero(t, movie.Priority)
+
+		w = serveJSON(router, "POST", path, token, nil)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = serveJSON(router, "POST", "/movies/999/acquire", token, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = serveJSON(router, "GET", "/movies", token, nil)
+		assert.ElementsMatch(t, []string{"Alien", "Dune: Part Two"}, movieTitles(t, w))
+	})
+
+	t.Run("Update Keeps Status", func(t *testing.T) {
+		w := serveJSON(router, "PUT", fmt.Sprintf("/movies/%d", wanted.ID), token, models.Movie{Title: "Dune: Part Two", Year: 2024})
+		require.Equal(t, http.StatusOK, w.Code)
+		var movie models.Movie
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &movie))
+		assert.Equal(t, models.StatusOwned, movie.Status)
+	})
+}
@@ -1,11 +1,11 @@
 package testutil
 
 import (
-	"fmt"
 	"net/http"
 	"testing"
 	"time"
 
+	"github.com/MichaelKlank/movie-collector/backend/auth"
 	"github.com/MichaelKlank/movie-collector/backend/cache"
 	"github.com/MichaelKlank/movie-collector/backend/handlers"
 	"github.com/MichaelKlank/movie-collector/backend/models"
@@ -26,7 +26,7 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 	}
 
 	// Migrate the schema
-	err = db.AutoMigrate(&models.Movie{})
+	err = db.AutoMigrate(&models.Movie{}, &models.User{}, &models.APIToken{}, &models.RevocationEntry{}, &models.Copy{}, &models.Location{}, &models.Loan{}, &models.Genre{}, &models.Person{}, &models.Credit{})
 	if err != nil {
 		t.Fatalf("Failed to migrate test database: %v", err)
 	}
@@ -34,6 +34,33 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 	return db
 }
 
+// TestJWTConfig liefert eine JWT-Konfiguration mit festem Secret für Tests
+func TestJWTConfig() *auth.JWTConfig {
+	return &auth.JWTConfig{
+		Secret:            []byte("test-secret"),
+		Expiration:        time.Hour,
+		RefreshExpiration: 24 * time.Hour,
+		CookieHttpOnly:    true,
+		CookieSameSite:    "Lax",
+	}
+}
+
+// CreateTestUser legt einen Benutzer mit der angegebenen Rolle an
+// und liefert ihn zusammen mit einem gültigen Access-Token
+func CreateTestUser(t *testing.T, db *gorm.DB, username, role string) (models.User, string) {
+	user := models.User{Username: username, PasswordHash: "not-a-real-hash", Role: role}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+
+	token, err := TestJWTConfig().GenerateAccessToken(user.ID, user.Username, user.Role)
+	if err != nil {
+		t.Fatalf("Failed to generate test token: %v", err)
+	}
+
+	return user, token
+}
+
 // InitTestCache initialisiert den Test-Cache
 func InitTestCache() {
 	// Für Tests verwenden wir immer den In-Memory-Cache
@@ -48,9 +75,30 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 	InitTestCache()
 
 	// Initialize dependencies
+	jwtConfig := TestJWTConfig()
+	userRepo := repositories.NewUserRepository(db)
 	movieRepo := repositories.NewMovieRepository(db)
-	movieService := services.NewMovieService(movieRepo)
+	authService := services.NewAuthService(userRepo, jwtConfig, repositories.NewRevocationRepository(db))
+	authHandler := handlers.NewAuthHandler(authService, jwtConfig)
+	userHandler := handlers.NewUserHandler(authService)
+	apiTokenRepo := repositories.NewAPITokenRepository(db)
+	apiTokenService := services.NewAPITokenService(apiTokenRepo, userRepo)
+	apiTokenHandler := handlers.NewAPITokenHandler(apiTokenService)
+	locationRepo := repositories.NewLocationRepository(db)
+	movieService := services.NewMovieService(movieRepo, locationRepo)
 	movieHandler := handlers.NewMovieHandler(movieService)
+	copyRepo := repositories.NewCopyRepository(db)
+	copyService := services.NewCopyService(copyRepo, movieRepo, locationRepo)
+	copyHandler := handlers.NewCopyHandler(copyService)
+	locationService := services.NewLocationService(locationRepo, movieRepo)
+	locationHandler := handlers.NewLocationHandler(locationService)
+	loanRepo := repositories.NewLoanRepository(db)
+	loanService := services.NewLoanService(loanRepo, movieRepo)
+	loanHandler := handlers.NewLoanHandler(loanService)
+	metadataRepo := repositories.NewMetadataRepository(db)
+	metadataService := services.NewMetadataService(tmdb.Shared(), movieRepo, metadataRepo)
+	metadataHandler := handlers.NewMetadataHandler(metadataService)
+	tmdbHandler := handlers.NewTMDBHandler(tmdb.Shared())
 	imageHandler := handlers.NewImageHandler(movieService)
 	versionHandler := handlers.NewVersionHandler()
 
@@ -97,79 +145,133 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 	// Version route
 	r.GET("/version", versionHandler.GetVersion)
 
-	// Movie routes mit Cache für GET-Anfragen
-	r.GET("/movies", gincache.CachePage(cache.RedisStore, 2*time.Minute, movieHandler.GetMovies))
-	r.GET("/movies/:id", gincache.CachePage(cache.RedisStore, 5*time.Minute, movieHandler.GetMovie))
-	r.POST("/movies", func(c *gin.Context) {
+	// Auth routes
+	r.POST("/auth/register", authHandler.Register)
+	r.POST("/auth/login", authHandler.Login)
+	r.POST("/auth/refresh", authHandler.Refresh)
+	r.POST("/auth/logout", authHandler.Logout)
+
+	// Abmelden auf allen Geräten erfordert eine Anmeldung
+	r.POST("/auth/logout-all", auth.AuthMiddleware(string(jwtConfig.Secret), false, apiTokenService), auth.DenyAPITokens(), authHandler.LogoutAll)
+
+	// Persönliche API-Tokens lassen sich nur mit einer Anmeldung verwalten, nicht mit einem API-Token
+	tokens := r.Group("/auth/tokens", auth.AuthMiddleware(string(jwtConfig.Secret), false, apiTokenService), auth.DenyAPITokens())
+	tokens.GET("", apiTokenHandler.ListTokens)
+	tokens.POST("", apiTokenHandler.CreateToken)
+	tokens.DELETE("/:id", apiTokenHandler.RevokeToken)
+
+	// Authentifizierung für Film- und Bildrouten
+	movies := r.Group("/movies", auth.AuthMiddleware(string(jwtConfig.Secret), true, apiTokenService))
+	movieEditors := movies.Group("", auth.RequireRole(auth.RoleEditor))
+	movieAdmins := movies.Group("", auth.RequireRole(auth.RoleAdmin))
+
+	// Movie routes mit Cache für GET-Anfragen (getrennt pro Benutzer)
+	movies.GET("", cache.CachePageByUser(cache.RedisStore, 2*time.Minute, movieHandler.GetMovies))
+	movies.GET("/search", cache.CachePageByUser(cache.RedisStore, 1*time.Minute, movieHandler.SearchMovies))
+	movies.GET("/index", cache.CachePageByUser(cache.RedisStore, 2*time.Minute, movieHandler.GetMovieIndex))
+	movies.GET("/:id", cache.CachePageByUser(cache.RedisStore, 5*time.Minute, movieHandler.GetMovie))
+	movieEditors.POST("", func(c *gin.Context) {
 		movieHandler.CreateMovie(c)
 		// Cache nach Erstellung eines Films invalidieren
 		cache.ClearAllCaches()
 	})
-	r.PUT("/movies/:id", func(c *gin.Context) {
+	movieEditors.PUT("/:id", func(c *gin.Context) {
 		movieHandler.UpdateMovie(c)
 		// Cache nach Update eines Films invalidieren
 		cache.ClearAllCaches()
 	})
-	r.DELETE("/movies/:id", func(c *gin.Context) {
+	movieAdmins.DELETE("/:id", func(c *gin.Context) {
 		movieHandler.DeleteMovie(c)
 		// Cache nach Löschen eines Films invalidieren
 		cache.ClearAllCaches()
 	})
 
-	// Image routes
-	r.POST("/movies/:id/image", imageHandler.UploadImage)
-	r.GET("/movies/:id/image", imageHandler.GetImage)
-	r.DELETE("/movies/:id/image", imageHandler.DeleteImage)
+	// Film von der Wunschliste in den Bestand übernehmen
+	movieEditors.POST("/:id/acquire", func(c *gin.Context) {
+		movieHandler.AcquireMovie(c)
+		cache.ClearAllCaches()
+	})
+	movieEditors.POST("/:id/metadata", func(c *gin.Context) {
+		metadataHandler.SyncMovieMetadata(c)
+		cache.ClearAllCaches()
+	})
 
-	// TMDB routes mit Cache
-	r.GET("/tmdb/test", func(c *gin.Context) {
-		client := tmdb.NewClient()
-		if err := client.TestConnection(); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	// Exemplar-Routen; Änderungen invalidieren den Cache, da GET /movies/:id die Exemplare enthält
+	movies.GET("/:id/copies", copyHandler.ListCopies)
+	movies.GET("/:id/copies/:copyId", copyHandler.GetCopy)
+	movieEditors.POST("/:id/copies", func(c *gin.Context) {
+		copyHandler.CreateCopy(c)
+		cache.ClearAllCaches()
+	})
+	movieEditors.PUT("/:id/copies/:copyId", func(c *gin.Context) {
+		copyHandler.UpdateCopy(c)
+		cache.ClearAllCaches()
+	})
+	movieAdmins.DELETE("/:id/copies/:copyId", func(c *gin.Context) {
+		copyHandler.DeleteCopy(c)
+		cache.ClearAllCaches()
 	})
 
-	r.GET("/tmdb/search", gincache.CachePage(cache.RedisStore, time.Minute, func(c *gin.Context) {
-		query := c.Query("query")
-		if query == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "query parameter is required"})
-			return
-		}
+	// Image routes
+	movieEditors.POST("/:id/image", imageHandler.UploadImage)
+	movies.GET("/:id/image", imageHandler.GetImage)
+	movieAdmins.DELETE("/:id/image", imageHandler.DeleteImage)
 
-		client := tmdb.NewClient()
-		movies, err := client.SearchMovies(query)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
-		}
+	// Lagerorte verwenden dieselben Rollen wie die Filmrouten
+	locations := r.Group("/locations", auth.AuthMiddleware(string(jwtConfig.Secret), true, apiTokenService))
+	locationEditors := locations.Group("", auth.RequireRole(auth.RoleEditor))
+	locationAdmins := locations.Group("", auth.RequireRole(auth.RoleAdmin))
+	locations.GET("", locationHandler.ListLocations)
+	locations.GET("/:id", locationHandler.GetLocation)
+	locations.GET("/:id/movies", locationHandler.GetLocationMovies)
+	locationEditors.POST("", locationHandler.CreateLocation)
+	locationEditors.PUT("/:id", func(c *gin.Context) {
+		locationHandler.UpdateLocation(c)
+		cache.ClearAllCaches()
+	})
+	locationAdmins.DELETE("/:id", func(c *gin.Context) {
+		locationHandler.DeleteLocation(c)
+		cache.ClearAllCaches()
+	})
 
-		c.JSON(http.StatusOK, movies)
-	}))
+	// Ausleihen; Verleihen und Rückgabe invalidieren den Cache, da GET /movies/:id die laufende Ausleihe enthält
+	movies.GET("/:id/loans", auth.RequireRole(auth.RoleViewer), loanHandler.GetMovieLoans)
+	movieEditors.POST("/:id/loans", func(c *gin.Context) {
+		loanHandler.LendMovie(c)
+		cache.ClearAllCaches()
+	})
+	movieEditors.POST("/:id/return", func(c *gin.Context) {
+		loanHandler.ReturnMovie(c)
+		cache.ClearAllCaches()
+	})
+	loans := r.Group("/loans", auth.AuthMiddleware(string(jwtConfig.Secret), false, apiTokenService))
+	loans.GET("", loanHandler.ListActiveLoans)
+	loans.GET("/overdue", loanHandler.ListOverdueLoans)
 
-	r.GET("/tmdb/movie/:id", gincache.CachePage(cache.RedisStore, time.Hour, func(c *gin.Context) {
-		idStr := c.Param("id")
-		if idStr == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "movie ID is required"})
-			return
-		}
+	// Übersichten nach Person und Genre
+	people := r.Group("/people", auth.AuthMiddleware(string(jwtConfig.Secret), true, apiTokenService))
+	people.GET("/:id/movies", metadataHandler.GetPersonMovies)
+	genres := r.Group("/genres", auth.AuthMiddleware(string(jwtConfig.Secret), true, apiTokenService))
+	genres.GET("", metadataHandler.ListGenres)
+	genres.GET("/:id/movies", metadataHandler.GetGenreMovies)
 
-		var id int
-		if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid movie ID"})
-			return
-		}
+	// Administrative Routen
+	admin := r.Group("", auth.AuthMiddleware(string(jwtConfig.Secret), false, apiTokenService), auth.RequireRole(auth.RoleAdmin))
+	admin.GET("/users", userHandler.ListUsers)
+	admin.PUT("/users/:id/role", userHandler.UpdateUserRole)
+	admin.POST("/cache/flush", func(c *gin.Context) {
+		cache.ClearAllCaches()
+		c.JSON(http.StatusOK, gin.H{"message": "Cache flushed successfully"})
+	})
 
-		client := tmdb.NewClient()
-		movie, err := client.GetMovieDetails(id)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
-		}
+	// TMDB routes mit Cache
+	admin.GET("/tmdb/test", tmdbHandler.TestConnection)
+	admin.GET("/tmdb/stats", tmdbHandler.GetStats)
 
-		c.JSON(http.StatusOK, movie)
-	}))
+	r.GET("/tmdb/search", gincache.CachePage(cache.RedisStore, time.Minute, tmdbHandler.SearchMovies))
+	r.GET("/tmdb/movie/:id", gincache.CachePage(cache.RedisStore, time.Hour, tmdbHandler.GetMovie))
+	r.GET("/tmdb/tv/:id", gincache.CachePage(cache.RedisStore, time.Hour, tmdbHandler.GetTVShow))
+	r.GET("/tmdb/tv/:id/season/:season", gincache.CachePage(cache.RedisStore, time.Hour, tmdbHandler.GetSeason))
 
 	return r
 }
@@ -0,0 +1,131 @@
+package tmdb
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultCacheSize ist die Anzahl der Antworten, die der Cache höchstens hält
+const DefaultCacheSize = 1000
+
+// Cache hält dekodierte TMDB-Antworten für eine begrenzte Zeit (TTL)
+// Ist er voll, wird die am längsten nicht mehr gelesene Antwort verdrängt (LRU).
+// Zwischengespeicherte Werte werden mit allen Lesern geteilt und dürfen nicht verändert werden.
+type Cache struct {
+	mutex    sync.Mutex
+	capacity int
+	ttl      time.Duration
+	items    map[string]*list.Element
+	// Vorne steht der zuletzt gelesene oder geschriebene Eintrag
+	order *list.List
+
+	hits, misses, evictions atomic.Int64
+}
+
+type cacheEntry struct {
+	key     string
+	value   interface{}
+	expires time.Time
+}
+
+// CacheStats sind die Zähler eines Caches
+type CacheStats struct {
+	Entries   int   `json:"entries" example:"250"`
+	Hits      int64 `json:"hits" example:"900"`
+	Misses    int64 `json:"misses" example:"120"`
+	Evictions int64 `json:"evictions" example:"0"`
+}
+
+// NewCache erzeugt einen Cache mit DefaultCacheSize Einträgen und einer TTL von 24 Stunden
+func NewCache() *Cache {
+	return NewCacheWithLimits(DefaultCacheSize, 24*time.Hour)
+}
+
+// NewCacheWithLimits erzeugt einen Cache mit höchstens capacity Einträgen, die nach ttl ablaufen
+func NewCacheWithLimits(capacity int, ttl time.Duration) *Cache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &Cache{
+		capacity: capacity,
+		ttl:      ttl,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Set speichert einen Wert mit der TTL des Caches
+func (c *Cache) Set(key string, value interface{}) {
+	c.SetWithTTL(key, value, c.ttl)
+}
+
+// SetWithTTL speichert einen Wert, der nach ttl abläuft
+func (c *Cache) SetWithTTL(key string, value interface{}, ttl time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry := &cacheEntry{key: key, value: value, expires: time.Now().Add(ttl)}
+	if element, ok := c.items[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.items[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+		c.evictions.Add(1)
+	}
+}
+
+// Get liefert einen nicht abgelaufenen Wert und zählt Treffer und Fehlschläge
+func (c *Cache) Get(key string) (interface{}, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		c.misses.Add(1)
+		return nil, false
+	}
+	entry := element.Value.(*cacheEntry)
+	if time.Now().After(entry.expires) {
+		c.remove(element)
+		c.misses.Add(1)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	c.hits.Add(1)
+	return entry.value, true
+}
+
+func (c *Cache) Delete(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if element, ok := c.items[key]; ok {
+		c.remove(element)
+	}
+}
+
+// Len liefert die Anzahl der Einträge einschließlich noch nicht entfernter abgelaufener
+func (c *Cache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.order.Len()
+}
+
+// Stats liefert die Zähler des Caches
+func (c *Cache) Stats() CacheStats {
+	return CacheStats{
+		Entries:   c.Len(),
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+	}
+}
+
+func (c *Cache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.items, element.Value.(*cacheEntry).key)
+}
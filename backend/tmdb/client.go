@@ -1,79 +1,123 @@
 package tmdb
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math/rand/v2"
 	"net/http"
 	"net/url"
 	"os"
+	"reflect"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
 var defaultBaseURL = "https://api.themoviedb.org/3"
 
+// Standardwerte für Zeitüberschreitung und Wiederholungen
+const (
+	DefaultTimeout    = 10 * time.Second
+	DefaultMaxRetries = 3
+	DefaultRetryDelay = 250 * time.Millisecond
+	// Längere Wartezeiten aus Retry-After werden nicht abgewartet, sondern als ErrRateLimited gemeldet
+	maxRetryAfter = 30 * time.Second
+	// Präfix der Antworten im zweiten Cache-Speicher
+	storePrefix = "tmdb:"
+)
+
+// Store ist ein optionaler zweiter Cache-Speicher hinter dem Cache im Prozess, z. B. cache.RedisStore
+// Er enthält die Rohantworten und wird von allen Instanzen des Servers geteilt.
+type Store interface {
+	Get(key string, value interface{}) error
+	Set(key string, value interface{}, expire time.Duration) error
+}
+
 type Client struct {
 	apiKey     string
 	imageURL   string
 	baseURL    string
 	httpClient *http.Client
-	cache      *Cache
-	CacheTTL   time.Duration
-}
-
-type Cache struct {
-	mutex sync.RWMutex
-	items map[string]struct{}
+	// cache hält dekodierte Antworten, Schlüssel ist Endpunkt samt Parametern
+	cache *Cache
+	// CacheTTL ist die Lebensdauer zwischengespeicherter Antworten in beiden Cache-Stufen
+	CacheTTL time.Duration
+	store    Store
+	// MaxRetries begrenzt die Wiederholungen nach 429, 5xx und Verbindungsfehlern
+	MaxRetries int
+	// RetryDelay ist die Basis des exponentiellen Backoffs mit Jitter
+	RetryDelay time.Duration
+	// Locale sind Sprache und Region der Anfragen ohne eigene Angabe
+	Locale Locale
+	// limiter wird über atomic.Pointer gelesen, da SetRateLimit ihn bei laufenden Anfragen austauschen kann
+	limiter atomic.Pointer[tokenBucket]
+	// Identische gleichzeitige Anfragen werden nur einmal an TMDB gesendet
+	inflight singleflight.Group
+	stats    counters
 }
 
-func NewCache() *Cache {
-	return &Cache{
-		items: make(map[string]struct{}),
-	}
-}
-
-func (c *Cache) Lock() {
-	c.mutex.Lock()
-}
+var (
+	sharedClient *Client
+	sharedOnce   sync.Once
+)
 
-func (c *Cache) Unlock() {
-	c.mutex.Unlock()
+// Shared liefert den gemeinsamen Client des Servers
+// Alle Routen und Dienste teilen sich so Limiter, laufende Anfragen und Zähler.
+func Shared() *Client {
+	sharedOnce.Do(func() {
+		sharedClient = NewClient()
+	})
+	return sharedClient
 }
 
-func (c *Cache) RLock() {
-	c.mutex.RLock()
+type Movie struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	// OriginalTitle ist der Titel in der Originalsprache, Title der lokalisierte
+	OriginalTitle    string   `json:"original_title"`
+	OriginalLanguage string   `json:"original_language"`
+	Overview         string   `json:"overview"`
+	ReleaseDate      string   `json:"release_date"`
+	PosterPath       string   `json:"poster_path"`
+	VoteAverage      float32  `json:"vote_average"`
+	Runtime          int      `json:"runtime,omitempty"`
+	Genres           []Genre  `json:"genres,omitempty"`
+	Credits          *Credits `json:"credits,omitempty"`
+	// MediaType ist nur bei Serien- und Mehrfachsuche gesetzt ("movie" oder "tv")
+	MediaType string `json:"media_type,omitempty"`
 }
 
-func (c *Cache) RUnlock() {
-	c.mutex.RUnlock()
+type Genre struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
 }
 
-func (c *Cache) Set(key string) {
-	c.Lock()
-	defer c.Unlock()
-	c.items[key] = struct{}{}
+// Credits enthält Besetzung und Stab eines Films (append_to_response=credits)
+type Credits struct {
+	Cast []CastMember `json:"cast"`
+	Crew []CrewMember `json:"crew"`
 }
 
-func (c *Cache) Get(key string) bool {
-	c.RLock()
-	defer c.RUnlock()
-	_, exists := c.items[key]
-	return exists
+type CastMember struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Character   string `json:"character"`
+	Order       int    `json:"order"`
+	ProfilePath string `json:"profile_path"`
 }
 
-func (c *Cache) Delete(key string) {
-	c.Lock()
-	defer c.Unlock()
-	delete(c.items, key)
-}
-
-type Movie struct {
-	ID          int     `json:"id"`
-	Title       string  `json:"title"`
-	Overview    string  `json:"overview"`
-	ReleaseDate string  `json:"release_date"`
-	PosterPath  string  `json:"poster_path"`
-	VoteAverage float32 `json:"vote_average"`
+type CrewMember struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Job         string `json:"job"`
+	Department  string `json:"department"`
+	ProfilePath string `json:"profile_path"`
 }
 
 type Response struct {
@@ -81,58 +125,326 @@ type Response struct {
 }
 
 func NewClient() *Client {
-	return &Client{
-		apiKey:     os.Getenv("TMDB_API_KEY"),
-		imageURL:   "https://image.tmdb.org/t/p/w500",
-		baseURL:    defaultBaseURL,
-		httpClient: &http.Client{},
-		cache:      NewCache(),
-		CacheTTL:   24 * time.Hour,
-	}
+	return NewClientWithBaseURL(defaultBaseURL)
 }
 
 func NewClientWithBaseURL(baseURL string) *Client {
-	return &Client{
+	c := &Client{
 		apiKey:     os.Getenv("TMDB_API_KEY"),
 		imageURL:   "https://image.tmdb.org/t/p/w500",
 		baseURL:    baseURL,
-		httpClient: &http.Client{},
+		httpClient: &http.Client{Timeout: DefaultTimeout},
 		cache:      NewCache(),
 		CacheTTL:   24 * time.Hour,
+		MaxRetries: DefaultMaxRetries,
+		RetryDelay: DefaultRetryDelay,
+		Locale:     Locale{Language: DefaultLanguage},
 	}
+	c.limiter.Store(newTokenBucket(DefaultRateLimit, DefaultBurst))
+	return c
+}
+
+// UseStore setzt den zweiten Cache-Speicher; vor der ersten Anfrage aufzurufen
+func (c *Client) UseStore(store Store) {
+	c.store = store
+}
+
+// SetRateLimit ändert den Limiter auf rate Anfragen pro Sekunde mit bis zu burst Anfragen am Stück
+// Laufende Anfragen warten noch am bisherigen Limiter, alle weiteren am neuen.
+func (c *Client) SetRateLimit(rate float64, burst int) {
+	c.limiter.Store(newTokenBucket(rate, burst))
+}
+
+// Stats liefert die Zähler der Anfragen und des Caches seit dem Start
+func (c *Client) Stats() Stats {
+	stats := c.stats.snapshot()
+	stats.Cache = c.cache.Stats()
+	return stats
 }
 
 func (c *Client) SearchMovies(query string) ([]Movie, error) {
-	escapedQuery := url.QueryEscape(query)
-	url := fmt.Sprintf("%s/search/movie?api_key=%s&query=%s", c.baseURL, c.apiKey, escapedQuery)
-	resp, err := c.httpClient.Get(url)
+	return c.SearchMoviesContext(context.Background(), query)
+}
+
+// SearchMoviesContext sucht Filme nach Titel in c.Locale; ctx begrenzt die Anfrage einschließlich aller Wiederholungen
+func (c *Client) SearchMoviesContext(ctx context.Context, query string) ([]Movie, error) {
+	return c.SearchMoviesLocalized(ctx, query, c.Locale)
+}
+
+// SearchMoviesLocalized sucht Filme nach Titel in der angegebenen Sprache und Region
+// Treffer ohne übersetzte Beschreibung erhalten die englische aus einer zweiten Suche; schlägt
+// diese fehl, bleiben die Beschreibungen leer.
+func (c *Client) SearchMoviesLocalized(ctx context.Context, query string, locale Locale) ([]Movie, error) {
+	return c.searchLocalized(locale, func(locale Locale) ([]Movie, error) {
+		return c.searchMovies(ctx, query, locale)
+	})
+}
+
+// searchLocalized führt eine Suche in locale aus und ergänzt fehlende Beschreibungen aus derselben Suche auf Englisch
+func (c *Client) searchLocalized(locale Locale, search func(Locale) ([]Movie, error)) ([]Movie, error) {
+	movies, err := search(locale)
+	if err != nil || !locale.needsFallback() || !missingOverview(movies) {
+		return movies, err
+	}
+
+	fallback, err := search(locale.fallback())
 	if err != nil {
-		return nil, err
+		return movies, nil
 	}
-	defer resp.Body.Close()
+	overviews := make(map[string]string, len(fallback))
+	for _, movie := range fallback {
+		overviews[resultKey(movie)] = movie.Overview
+	}
+	// Die Treffer stammen womöglich aus dem Cache und werden deshalb kopiert
+	movies = append([]Movie(nil), movies...)
+	for i := range movies {
+		if movies[i].Overview == "" {
+			movies[i].Overview = overviews[resultKey(movies[i])]
+		}
+	}
+	return movies, nil
+}
 
+// resultKey unterscheidet Treffer der Mehrfachsuche; Filme und Serien haben getrennte IDs
+func resultKey(movie Movie) string {
+	return movie.MediaType + "/" + strconv.Itoa(movie.ID)
+}
+
+func (c *Client) searchMovies(ctx context.Context, query string, locale Locale) ([]Movie, error) {
 	var result Response
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := c.get(ctx, "/search/movie", locale.apply(url.Values{"query": {query}}), &result); err != nil {
 		return nil, err
 	}
-
 	return result.Results, nil
 }
 
+// GetMovieDetails lädt die Details eines Films einschließlich Genres, Laufzeit und Credits
 func (c *Client) GetMovieDetails(id int) (*Movie, error) {
-	url := fmt.Sprintf("%s/movie/%d?api_key=%s", c.baseURL, id, c.apiKey)
-	resp, err := c.httpClient.Get(url)
+	return c.GetMovieDetailsContext(context.Background(), id)
+}
+
+// GetMovieDetailsContext lädt die Details eines Films in c.Locale; ctx begrenzt die Anfrage einschließlich aller Wiederholungen
+func (c *Client) GetMovieDetailsContext(ctx context.Context, id int) (*Movie, error) {
+	return c.GetMovieDetailsLocalized(ctx, id, c.Locale)
+}
+
+// GetMovieDetailsLocalized lädt die Details eines Films in der angegebenen Sprache
+// Fehlt die übersetzte Beschreibung, wird die englische übernommen.
+func (c *Client) GetMovieDetailsLocalized(ctx context.Context, id int, locale Locale) (*Movie, error) {
+	movie, err := c.movieDetails(ctx, id, locale)
+	if err != nil || !locale.needsFallback() || movie.Overview != "" {
+		return movie, err
+	}
+	if fallback, err := c.movieDetails(ctx, id, locale.fallback()); err == nil {
+		movie.Overview = fallback.Overview
+	}
+	return movie, nil
+}
+
+func (c *Client) movieDetails(ctx context.Context, id int, locale Locale) (*Movie, error) {
+	// Die Region wirkt sich auf die Details nicht aus und würde nur den Cache aufteilen
+	params := Locale{Language: locale.Language}.apply(url.Values{"append_to_response": {"credits"}})
+	var movie Movie
+	if err := c.get(ctx, fmt.Sprintf("/movie/%d", id), params, &movie); err != nil {
+		return nil, err
+	}
+	return &movie, nil
+}
+
+func missingOverview(movies []Movie) bool {
+	for _, movie := range movies {
+		if movie.Overview == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// get ruft einen Endpunkt der TMDB-API ab und dekodiert die Antwort in dest (nil verwirft sie)
+// Antworten mit dest werden zwischengespeichert: dekodiert im Cache des Prozesses, roh im
+// zweiten Cache-Speicher. Gleichzeitige identische Aufrufe teilen sich eine Anfrage. Sie läuft
+// unabhängig vom Kontext des ersten Aufrufers weiter, damit dessen Abbruch die übrigen nicht trifft;
+// ctx begrenzt nur das Warten.
+func (c *Client) get(ctx context.Context, path string, params url.Values, dest interface{}) error {
+	c.stats.calls.Add(1)
+	if params == nil {
+		params = url.Values{}
+	}
+	key := path + "?" + params.Encode()
+	cacheable := dest != nil
+
+	if cacheable {
+		if cached, ok := c.cache.Get(key); ok {
+			reflect.ValueOf(dest).Elem().Set(reflect.ValueOf(cached))
+			return nil
+		}
+	}
+
+	leader := false
+	results := c.inflight.DoChan(key, func() (interface{}, error) {
+		leader = true
+		return c.load(context.WithoutCancel(ctx), key, path, params, cacheable)
+	})
+
+	var result singleflight.Result
+	select {
+	case <-ctx.Done():
+		c.stats.errors.Add(1)
+		return &networkError{err: ctx.Err()}
+	case result = <-results:
+	}
+	if !leader {
+		c.stats.coalesced.Add(1)
+	}
+	if result.Err != nil {
+		c.stats.errors.Add(1)
+		return result.Err
+	}
+
+	if !cacheable {
+		return nil
+	}
+	if err := json.Unmarshal(result.Val.([]byte), dest); err != nil {
+		c.stats.errors.Add(1)
+		return fmt.Errorf("ungültige TMDB-Antwort: %w", err)
+	}
+	c.cache.SetWithTTL(key, reflect.ValueOf(dest).Elem().Interface(), c.CacheTTL)
+	return nil
+}
+
+// load liefert die Rohantwort aus dem zweiten Cache-Speicher oder von TMDB und legt sie dort ab
+func (c *Client) load(ctx context.Context, key, path string, params url.Values, cacheable bool) ([]byte, error) {
+	if !cacheable || c.store == nil {
+		return c.fetch(ctx, path, params)
+	}
+
+	var body []byte
+	if err := c.store.Get(storePrefix+key, &body); err == nil && len(body) > 0 {
+		c.stats.storeHits.Add(1)
+		return body, nil
+	}
+	body, err := c.fetch(ctx, path, params)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	// Ein nicht erreichbarer Speicher verhindert nur das Zwischenspeichern
+	_ = c.store.Set(storePrefix+key, body, c.CacheTTL)
+	return body, nil
+}
 
-	var movie Movie
-	if err := json.NewDecoder(resp.Body).Decode(&movie); err != nil {
+// fetch lädt die Rohantwort eines Endpunkts; jede Anfrage wartet zuvor auf ein Token des Limiters
+// Nach 429, 5xx und Verbindungsfehlern wird bis zu MaxRetries-mal wiederholt; die Wartezeit
+// ist Retry-After, sofern angegeben, sonst ein exponentiell wachsendes Intervall mit Jitter.
+func (c *Client) fetch(ctx context.Context, path string, params url.Values) ([]byte, error) {
+	query := url.Values{"api_key": {c.apiKey}}
+	for name, values := range params {
+		query[name] = values
+	}
+	endpoint := c.baseURL + path + "?" + query.Encode()
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			c.stats.retries.Add(1)
+		}
+		if wait, err := c.limiter.Load().wait(ctx); err != nil {
+			return nil, &networkError{err: err}
+		} else if wait > 0 {
+			c.stats.throttled.Add(1)
+		}
+
+		body, err := c.do(ctx, endpoint)
+		if err == nil || attempt >= c.MaxRetries || !retryable(err) || ctx.Err() != nil {
+			return body, err
+		}
+
+		wait := c.backoff(attempt)
+		if retryAfter := RetryAfter(err); retryAfter > 0 {
+			if retryAfter > maxRetryAfter {
+				return nil, err
+			}
+			wait = retryAfter
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) do(ctx context.Context, endpoint string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
 		return nil, err
 	}
+	c.stats.requests.Add(1)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// Die URL enthält den API-Schlüssel und gehört nicht in die Fehlermeldung
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, &networkError{err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &APIError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
+		var body struct {
+			StatusMessage string `json:"status_message"`
+		}
+		if json.NewDecoder(io.LimitReader(resp.Body, 4096)).Decode(&body) == nil {
+			apiErr.Message = body.StatusMessage
+		}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			c.stats.rateLimited.Add(1)
+		}
+		return nil, apiErr
+	}
 
-	return &movie, nil
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &networkError{err: err}
+	}
+	return body, nil
+}
+
+// backoff liefert die Wartezeit vor der Wiederholung attempt+1: RetryDelay * 2^attempt, davon 50-100 %
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := c.RetryDelay << attempt
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+func retryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.retryable()
+	}
+	var netErr *networkError
+	return errors.As(err, &netErr)
+}
+
+// parseRetryAfter liest Retry-After als Sekunden oder HTTP-Datum
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
 }
 
 func (c *Client) GetImageURL(path string) string {
@@ -146,18 +458,12 @@ func (c *Client) GetImageURL(path string) string {
 }
 
 func (c *Client) TestConnection() error {
-	url := fmt.Sprintf("%s/configuration?api_key=%s", c.baseURL, c.apiKey)
-	resp, err := c.httpClient.Get(url)
-	if err != nil {
-		return fmt.Errorf("fehler bei der TMDB-Verbindung: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("TMDB-API-Fehler: %d", resp.StatusCode)
-	}
+	return c.TestConnectionContext(context.Background())
+}
 
-	return nil
+// TestConnectionContext prüft Erreichbarkeit und API-Schlüssel über den Konfigurationsendpunkt
+func (c *Client) TestConnectionContext(ctx context.Context) error {
+	return c.get(ctx, "/configuration", nil, nil)
 }
 
 func (c *Client) GetBaseURL() string {
@@ -0,0 +1,75 @@
+package tmdb
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Fehlerarten der TMDB-API; APIError und Netzwerkfehler lassen sich per errors.Is darauf prüfen
+var (
+	ErrUnauthorized = errors.New("TMDB: API-Schlüssel fehlt oder ist ungültig")
+	ErrNotFound     = errors.New("TMDB: Eintrag nicht gefunden")
+	ErrRateLimited  = errors.New("TMDB: Anfragelimit überschritten")
+	ErrUnavailable  = errors.New("TMDB: Dienst nicht erreichbar")
+)
+
+// APIError ist eine Antwort der TMDB-API mit einem Fehlerstatus
+type APIError struct {
+	StatusCode int
+	// Message ist die status_message aus der Antwort, falls vorhanden
+	Message string
+	// RetryAfter ist die von TMDB verlangte Wartezeit (Retry-After), falls angegeben
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("TMDB-API-Fehler %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("TMDB-API-Fehler %d", e.StatusCode)
+}
+
+// Unwrap ordnet den Status einer der Fehlerarten zu
+func (e *APIError) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.StatusCode >= http.StatusInternalServerError:
+		return ErrUnavailable
+	}
+	return nil
+}
+
+// retryable meldet, ob eine Wiederholung Aussicht auf Erfolg hat
+func (e *APIError) retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// networkError ist ein Verbindungsfehler oder eine Zeitüberschreitung auf dem Weg zu TMDB
+type networkError struct {
+	err error
+}
+
+func (e *networkError) Error() string {
+	return fmt.Sprintf("fehler bei der TMDB-Verbindung: %v", e.err)
+}
+
+// Unwrap liefert sowohl ErrUnavailable als auch die Ursache, z. B. context.DeadlineExceeded
+func (e *networkError) Unwrap() []error {
+	return []error{ErrUnavailable, e.err}
+}
+
+// RetryAfter liefert die von TMDB verlangte Wartezeit eines Fehlers oder 0
+func RetryAfter(err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.RetryAfter
+	}
+	return 0
+}
@@ -0,0 +1,63 @@
+package tmdb
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Standardwerte des Token-Buckets; TMDB erlaubt etwa 50 Anfragen pro Sekunde und IP,
+// der Client bleibt mit Abstand darunter, damit mehrere Instanzen Luft haben
+const (
+	DefaultRateLimit = 40
+	DefaultBurst     = 20
+)
+
+// tokenBucket begrenzt die Anfragen an TMDB auf rate pro Sekunde mit bis zu burst Anfragen am Stück
+// Wartende reservieren ihr Token sofort, sodass gleichzeitige Anfragen der Reihe nach drankommen.
+type tokenBucket struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst)}
+}
+
+// reserve entnimmt ein Token und liefert die Zeit, bis es verfügbar ist
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// wait blockiert, bis ein Token verfügbar ist, und liefert die Wartezeit
+func (b *tokenBucket) wait(ctx context.Context) (time.Duration, error) {
+	delay := b.reserve(time.Now())
+	if delay <= 0 {
+		return 0, nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return delay, ctx.Err()
+	case <-timer.C:
+		return delay, nil
+	}
+}
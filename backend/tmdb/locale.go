@@ -0,0 +1,84 @@
+package tmdb
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Standardsprache der Metadaten und Sprache, auf die bei fehlender Übersetzung ausgewichen wird
+const (
+	DefaultLanguage  = "de-DE"
+	FallbackLanguage = "en-US"
+)
+
+var (
+	// ISO 639-1, optional mit Land nach ISO 3166-1, z. B. "de" oder "de-DE"
+	languagePattern = regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`)
+	// ISO 3166-1, z. B. "DE"
+	regionPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// Locale bestimmt Sprache und Region der TMDB-Metadaten
+// Die Region beeinflusst Suchergebnisse und Veröffentlichungsdaten; leere Felder werden nicht gesendet.
+type Locale struct {
+	Language string
+	Region   string
+}
+
+// ValidLanguage prüft eine Sprache wie "de" oder "de-DE"
+func ValidLanguage(language string) bool {
+	return languagePattern.MatchString(language)
+}
+
+// ValidRegion prüft eine Region wie "DE"
+func ValidRegion(region string) bool {
+	return regionPattern.MatchString(region)
+}
+
+// LocaleFromEnv liest Sprache und Region aus TMDB_LANGUAGE und TMDB_REGION
+// Ungültige Werte werden einzeln gemeldet und durch DefaultLanguage bzw. keine Region ersetzt;
+// die Fehlermeldung nennt jeweils den Ersatz.
+func LocaleFromEnv() (Locale, error) {
+	locale := Locale{Language: DefaultLanguage}
+	var errs []error
+	if language := strings.TrimSpace(os.Getenv("TMDB_LANGUAGE")); language != "" {
+		if ValidLanguage(language) {
+			locale.Language = language
+		} else {
+			errs = append(errs, fmt.Errorf("ungültige TMDB-Sprache %q, verwende %s", language, DefaultLanguage))
+		}
+	}
+	if region := strings.TrimSpace(os.Getenv("TMDB_REGION")); region != "" {
+		if ValidRegion(region) {
+			locale.Region = region
+		} else {
+			errs = append(errs, fmt.Errorf("ungültige TMDB-Region %q, suche ohne Region", region))
+		}
+	}
+	return locale, errors.Join(errs...)
+}
+
+// needsFallback gibt an, ob leere Übersetzungen durch englische Texte ersetzt werden
+func (l Locale) needsFallback() bool {
+	return l.Language != "" && !strings.HasPrefix(l.Language, "en")
+}
+
+// fallback liefert die Locale für die englischen Ersatztexte
+func (l Locale) fallback() Locale {
+	return Locale{Language: FallbackLanguage, Region: l.Region}
+}
+
+// apply setzt Sprache und Region als Parameter einer Anfrage
+func (l Locale) apply(params url.Values) url.Values {
+	if l.Language != "" {
+		params.Set("language", l.Language)
+	}
+	if l.Region != "" {
+		params.Set("region", l.Region)
+	}
+	return params
+}
@@ -0,0 +1,41 @@
+package tmdb
+
+import "sync/atomic"
+
+// Stats sind die Zähler der Anfragen eines Clients seit dem Start
+type Stats struct {
+	// Calls sind die Aufrufe der Client-Methoden
+	Calls int64 `json:"calls" example:"120"`
+	// Coalesced sind Aufrufe, die eine bereits laufende identische Anfrage mitgenutzt haben
+	Coalesced int64 `json:"coalesced" example:"14"`
+	// Requests sind die tatsächlich an TMDB gesendeten HTTP-Anfragen einschließlich Wiederholungen
+	Requests int64 `json:"requests" example:"108"`
+	Retries  int64 `json:"retries" example:"2"`
+	// Throttled sind Anfragen, die auf ein Token des Limiters warten mussten
+	Throttled int64 `json:"throttled" example:"5"`
+	// RateLimited sind 429-Antworten von TMDB
+	RateLimited int64 `json:"rate_limited" example:"0"`
+	// Errors sind Aufrufe, die mit einem Fehler endeten
+	Errors int64 `json:"errors" example:"1"`
+	// StoreHits sind Antworten aus dem zweiten Cache-Speicher (Redis)
+	StoreHits int64 `json:"store_hits" example:"3"`
+	// Cache sind die Zähler des Caches im Prozess
+	Cache CacheStats `json:"cache"`
+}
+
+type counters struct {
+	calls, coalesced, requests, retries, throttled, rateLimited, errors, storeHits atomic.Int64
+}
+
+func (c *counters) snapshot() Stats {
+	return Stats{
+		Calls:       c.calls.Load(),
+		Coalesced:   c.coalesced.Load(),
+		Requests:    c.requests.Load(),
+		Retries:     c.retries.Load(),
+		Throttled:   c.throttled.Load(),
+		RateLimited: c.rateLimited.Load(),
+		Errors:      c.errors.Load(),
+		StoreHits:   c.storeHits.Load(),
+	}
+}
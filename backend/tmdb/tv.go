@@ -0,0 +1,211 @@
+package tmdb
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Medientypen der Suchtreffer; die Mehrfachsuche liefert außerdem Personen, die verworfen werden
+const (
+	MediaTypeMovie = "movie"
+	MediaTypeTV    = "tv"
+)
+
+// TVShow sind die Details einer Serie
+type TVShow struct {
+	ID               int      `json:"id"`
+	Name             string   `json:"name"`
+	OriginalName     string   `json:"original_name"`
+	OriginalLanguage string   `json:"original_language"`
+	Overview         string   `json:"overview"`
+	FirstAirDate     string   `json:"first_air_date"`
+	LastAirDate      string   `json:"last_air_date,omitempty"`
+	PosterPath       string   `json:"poster_path"`
+	VoteAverage      float32  `json:"vote_average"`
+	NumberOfSeasons  int      `json:"number_of_seasons,omitempty"`
+	NumberOfEpisodes int      `json:"number_of_episodes,omitempty"`
+	EpisodeRunTime   []int    `json:"episode_run_time,omitempty"`
+	Seasons          []Season `json:"seasons,omitempty"`
+	Genres           []Genre  `json:"genres,omitempty"`
+	Credits          *Credits `json:"credits,omitempty"`
+}
+
+// Season ist eine Staffel; Episodes ist nur in den Details einer Staffel gefüllt
+// Staffel 0 enthält bei TMDB die Specials.
+type Season struct {
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	Overview     string    `json:"overview"`
+	SeasonNumber int       `json:"season_number"`
+	EpisodeCount int       `json:"episode_count,omitempty"`
+	AirDate      string    `json:"air_date"`
+	PosterPath   string    `json:"poster_path"`
+	Episodes     []Episode `json:"episodes,omitempty"`
+}
+
+type Episode struct {
+	ID            int     `json:"id"`
+	Name          string  `json:"name"`
+	Overview      string  `json:"overview"`
+	SeasonNumber  int     `json:"season_number"`
+	EpisodeNumber int     `json:"episode_number"`
+	AirDate       string  `json:"air_date"`
+	Runtime       int     `json:"runtime,omitempty"`
+	StillPath     string  `json:"still_path"`
+	VoteAverage   float32 `json:"vote_average"`
+}
+
+// EpisodeRuntime ist die übliche Laufzeit einer Episode in Minuten, 0 wenn unbekannt
+func (s *TVShow) EpisodeRuntime() int {
+	if len(s.EpisodeRunTime) == 0 {
+		return 0
+	}
+	return s.EpisodeRunTime[0]
+}
+
+// EpisodeCount zählt die Episoden der Staffeln from bis to; ohne Staffeln (from 0) die aller Staffeln ohne Specials
+func (s *TVShow) EpisodeCount(from, to int) int {
+	if from == 0 {
+		return s.NumberOfEpisodes
+	}
+	count := 0
+	for _, season := range s.Seasons {
+		if season.SeasonNumber >= from && season.SeasonNumber <= to {
+			count += season.EpisodeCount
+		}
+	}
+	return count
+}
+
+// AsSearchResult bringt eine Serie in die Form der Suchtreffer (Titel, Datum, media_type)
+func (s TVShow) AsSearchResult() Movie {
+	return Movie{
+		ID:               s.ID,
+		Title:            s.Name,
+		OriginalTitle:    s.OriginalName,
+		OriginalLanguage: s.OriginalLanguage,
+		Overview:         s.Overview,
+		ReleaseDate:      s.FirstAirDate,
+		PosterPath:       s.PosterPath,
+		VoteAverage:      s.VoteAverage,
+		MediaType:        MediaTypeTV,
+	}
+}
+
+// multiResult ist ein Treffer der Mehrfach- oder Seriensuche; Serien verwenden name und first_air_date
+type multiResult struct {
+	Movie
+	Name         string `json:"name"`
+	OriginalName string `json:"original_name"`
+	FirstAirDate string `json:"first_air_date"`
+}
+
+type multiResponse struct {
+	Results []multiResult `json:"results"`
+}
+
+// SearchTVContext sucht Serien nach Titel in c.Locale
+func (c *Client) SearchTVContext(ctx context.Context, query string) ([]Movie, error) {
+	return c.SearchTVLocalized(ctx, query, c.Locale)
+}
+
+// SearchTVLocalized sucht Serien nach Titel; die Treffer haben die Form der Filmtreffer mit media_type "tv"
+// Fehlende Beschreibungen werden wie bei SearchMoviesLocalized auf Englisch ergänzt.
+func (c *Client) SearchTVLocalized(ctx context.Context, query string, locale Locale) ([]Movie, error) {
+	return c.searchLocalized(locale, func(locale Locale) ([]Movie, error) {
+		return c.searchResults(ctx, "/search/tv", MediaTypeTV, query, locale)
+	})
+}
+
+// SearchMultiContext sucht Filme und Serien zugleich in c.Locale
+func (c *Client) SearchMultiContext(ctx context.Context, query string) ([]Movie, error) {
+	return c.SearchMultiLocalized(ctx, query, c.Locale)
+}
+
+// SearchMultiLocalized sucht Filme und Serien zugleich; media_type unterscheidet die Treffer, Personen entfallen
+func (c *Client) SearchMultiLocalized(ctx context.Context, query string, locale Locale) ([]Movie, error) {
+	return c.searchLocalized(locale, func(locale Locale) ([]Movie, error) {
+		return c.searchResults(ctx, "/search/multi", "", query, locale)
+	})
+}
+
+// searchResults ruft eine Suche ab, deren Treffer Serien enthalten können
+// mediaType gilt für Treffer ohne eigenes media_type (Seriensuche).
+func (c *Client) searchResults(ctx context.Context, path, mediaType, query string, locale Locale) ([]Movie, error) {
+	var response multiResponse
+	if err := c.get(ctx, path, locale.apply(url.Values{"query": {query}}), &response); err != nil {
+		return nil, err
+	}
+
+	results := make([]Movie, 0, len(response.Results))
+	for _, result := range response.Results {
+		movie := result.Movie
+		if movie.MediaType == "" {
+			movie.MediaType = mediaType
+		}
+		switch movie.MediaType {
+		case MediaTypeMovie:
+		case MediaTypeTV:
+			movie.Title = result.Name
+			movie.OriginalTitle = result.OriginalName
+			movie.ReleaseDate = result.FirstAirDate
+		default:
+			continue
+		}
+		results = append(results, movie)
+	}
+	return results, nil
+}
+
+// GetTVDetailsContext lädt die Details einer Serie einschließlich Staffeln, Genres und Credits in c.Locale
+func (c *Client) GetTVDetailsContext(ctx context.Context, id int) (*TVShow, error) {
+	return c.GetTVDetailsLocalized(ctx, id, c.Locale)
+}
+
+// GetTVDetailsLocalized lädt die Details einer Serie; fehlt die übersetzte Beschreibung, wird die englische übernommen
+func (c *Client) GetTVDetailsLocalized(ctx context.Context, id int, locale Locale) (*TVShow, error) {
+	show, err := c.tvDetails(ctx, id, locale)
+	if err != nil || !locale.needsFallback() || show.Overview != "" {
+		return show, err
+	}
+	if fallback, err := c.tvDetails(ctx, id, locale.fallback()); err == nil {
+		show.Overview = fallback.Overview
+	}
+	return show, nil
+}
+
+func (c *Client) tvDetails(ctx context.Context, id int, locale Locale) (*TVShow, error) {
+	params := Locale{Language: locale.Language}.apply(url.Values{"append_to_response": {"credits"}})
+	var show TVShow
+	if err := c.get(ctx, fmt.Sprintf("/tv/%d", id), params, &show); err != nil {
+		return nil, err
+	}
+	return &show, nil
+}
+
+// GetSeasonDetailsContext lädt eine Staffel einer Serie mit ihren Episoden in c.Locale
+func (c *Client) GetSeasonDetailsContext(ctx context.Context, id, season int) (*Season, error) {
+	return c.GetSeasonDetailsLocalized(ctx, id, season, c.Locale)
+}
+
+// GetSeasonDetailsLocalized lädt eine Staffel mit ihren Episoden; fehlt die übersetzte Beschreibung der Staffel,
+// wird die englische übernommen
+func (c *Client) GetSeasonDetailsLocalized(ctx context.Context, id, season int, locale Locale) (*Season, error) {
+	details, err := c.seasonDetails(ctx, id, season, locale)
+	if err != nil || !locale.needsFallback() || details.Overview != "" {
+		return details, err
+	}
+	if fallback, err := c.seasonDetails(ctx, id, season, locale.fallback()); err == nil {
+		details.Overview = fallback.Overview
+	}
+	return details, nil
+}
+
+func (c *Client) seasonDetails(ctx context.Context, id, season int, locale Locale) (*Season, error) {
+	var details Season
+	if err := c.get(ctx, fmt.Sprintf("/tv/%d/season/%d", id, season), Locale{Language: locale.Language}.apply(url.Values{}), &details); err != nil {
+		return nil, err
+	}
+	return &details, nil
+}
@@ -51,6 +51,18 @@ describe("Header", () => {
         expect(screen.getByTestId("sbom-button")).toBeInTheDocument();
     });
 
+    it("sollte den Anmelde-Button anzeigen, wenn niemand angemeldet ist", () => {
+        renderWithTheme(<Header />);
+        expect(screen.getByTestId("login-button")).toBeInTheDocument();
+        expect(screen.queryByTestId("logout-button")).not.toBeInTheDocument();
+    });
+
+    it("sollte den Anmelde-Dialog öffnen, wenn auf den Anmelde-Button geklickt wird", () => {
+        renderWithTheme(<Header />);
+        fireEvent.click(screen.getByTestId("login-button"));
+        expect(screen.getByLabelText("Benutzername")).toBeInTheDocument();
+    });
+
     it("sollte das Theme umschalten, wenn auf den Theme-Button geklickt wird", () => {
         renderWithTheme(<Header />);
         const themeButton = screen.getByTestId("theme-toggle-button");
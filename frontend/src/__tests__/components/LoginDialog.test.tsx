@@ -0,0 +1,64 @@
+import { render, screen, fireEvent, waitFor } from "@testing-library/react";
+import { describe, it, expect, beforeEach, vi } from "vitest";
+import { AxiosError, InternalAxiosRequestConfig } from "axios";
+import { LoginDialog } from "../../components/LoginDialog";
+
+const { mockLogin } = vi.hoisted(() => ({ mockLogin: vi.fn() }));
+
+vi.mock("../../context/authContext", () => ({
+    useAuthContext: () => ({ login: mockLogin }),
+}));
+
+describe("LoginDialog", () => {
+    const mockOnClose = vi.fn();
+
+    beforeEach(() => {
+        vi.clearAllMocks();
+    });
+
+    const fillAndSubmit = () => {
+        fireEvent.change(screen.getByLabelText("Benutzername"), { target: { value: "alice" } });
+        fireEvent.change(screen.getByLabelText("Passwort"), { target: { value: "geheim123" } });
+        fireEvent.click(screen.getByRole("button", { name: "Anmelden" }));
+    };
+
+    it("sollte den Anmelde-Button erst mit Benutzername und Passwort freigeben", () => {
+        render(<LoginDialog open={true} onClose={mockOnClose} />);
+        expect(screen.getByRole("button", { name: "Anmelden" })).toBeDisabled();
+
+        fireEvent.change(screen.getByLabelText("Benutzername"), { target: { value: "alice" } });
+        fireEvent.change(screen.getByLabelText("Passwort"), { target: { value: "geheim123" } });
+        expect(screen.getByRole("button", { name: "Anmelden" })).toBeEnabled();
+    });
+
+    it("sollte sich anmelden und den Dialog schließen", async () => {
+        mockLogin.mockResolvedValueOnce(undefined);
+        render(<LoginDialog open={true} onClose={mockOnClose} />);
+
+        fillAndSubmit();
+
+        await waitFor(() => {
+            expect(mockOnClose).toHaveBeenCalled();
+        });
+        expect(mockLogin).toHaveBeenCalledWith("alice", "geheim123");
+    });
+
+    it("sollte bei falschen Anmeldedaten eine Fehlermeldung anzeigen", async () => {
+        const config = { headers: {} } as InternalAxiosRequestConfig;
+        mockLogin.mockRejectedValueOnce(
+            new AxiosError("Request failed", undefined, config, undefined, {
+                data: { error: "invalid credentials" },
+                status: 401,
+                statusText: "Unauthorized",
+                headers: {},
+                config,
+            })
+        );
+        render(<LoginDialog open={true} onClose={mockOnClose} />);
+
+        fillAndSubmit();
+
+        expect(await screen.findByText("Benutzername oder Passwort ist falsch")).toBeInTheDocument();
+        expect(mockOnClose).not.toHaveBeenCalled();
+    });
+});
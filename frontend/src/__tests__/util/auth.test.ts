@@ -0,0 +1,88 @@
+import { describe, it, expect, vi } from "vitest";
+import axios, { AxiosAdapter, AxiosError, InternalAxiosRequestConfig } from "axios";
+import { authErrorMessage, onSessionExpired, setupAuth } from "../../util/auth";
+
+const respond = (config: InternalAxiosRequestConfig, status: number) => {
+    const response = { data: {}, status, statusText: "", headers: {}, config };
+    if (status >= 400) {
+        return Promise.reject(new AxiosError("Request failed", undefined, config, undefined, response));
+    }
+    return Promise.resolve(response);
+};
+
+// Simuliert ein Backend, dessen Access-Token abgelaufen ist, bis die Sitzung erneuert wurde
+const createClient = (refreshStatus: number) => {
+    const calls: string[] = [];
+    let accessValid = false;
+    const adapter: AxiosAdapter = async (config) => {
+        calls.push(`${config.method} ${config.url}`);
+        if (config.url?.endsWith("/auth/refresh")) {
+            await new Promise((resolve) => setTimeout(resolve, 10));
+            accessValid = refreshStatus === 200;
+            return respond(config, refreshStatus);
+        }
+        return respond(config, accessValid ? 200 : 401);
+    };
+    const client = axios.create({ adapter });
+    setupAuth(client);
+    return { client, calls };
+};
+
+describe("setupAuth", () => {
+    it("sollte Cookies bei jeder Anfrage mitsenden", () => {
+        const { client } = createClient(200);
+        expect(client.defaults.withCredentials).toBe(true);
+    });
+
+    it("sollte die Sitzung bei 401 erneuern und die Anfrage wiederholen", async () => {
+        const { client, calls } = createClient(200);
+
+        const response = await client.post("/movies", { title: "Brazil" });
+
+        expect(response.status).toBe(200);
+        expect(calls.filter((call) => call.endsWith("/auth/refresh"))).toHaveLength(1);
+        expect(calls.filter((call) => call === "post /movies")).toHaveLength(2);
+    });
+
+    it("sollte gleichzeitige 401-Antworten mit einer einzigen Erneuerung beantworten", async () => {
+        const { client, calls } = createClient(200);
+
+        const responses = await Promise.all([client.get("/movies"), client.delete("/movies/1")]);
+
+        expect(responses.map((response) => response.status)).toEqual([200, 200]);
+        expect(calls.filter((call) => call.endsWith("/auth/refresh"))).toHaveLength(1);
+    });
+
+    it("sollte das Ende der Sitzung melden, wenn die Erneuerung fehlschlägt", async () => {
+        const { client, calls } = createClient(401);
+        const listener = vi.fn();
+        const unsubscribe = onSessionExpired(listener);
+
+        await expect(client.put("/movies/1", { title: "Brazil" })).rejects.toMatchObject({
+            response: { status: 401 },
+        });
+
+        unsubscribe();
+        expect(listener).toHaveBeenCalledTimes(1);
+        expect(calls.filter((call) => call === "put /movies/1")).toHaveLength(1);
+    });
+});
+
+describe("authErrorMessage", () => {
+    it("sollte fehlende Anmeldung und Berechtigung unterscheiden", () => {
+        const config = { headers: {} } as InternalAxiosRequestConfig;
+        const errorFor = (status: number) =>
+            new AxiosError("Request failed", undefined, config, undefined, {
+                data: {},
+                status,
+                statusText: "",
+                headers: {},
+                config,
+            });
+
+        expect(authErrorMessage(errorFor(401))).toBe("Bitte melden Sie sich an");
+        expect(authErrorMessage(errorFor(403))).toBe("Dafür fehlt Ihnen die Berechtigung");
+        expect(authErrorMessage(errorFor(500))).toBeNull();
+        expect(authErrorMessage(new Error("Netzwerkfehler"))).toBeNull();
+    });
+});
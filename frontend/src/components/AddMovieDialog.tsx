@@ -26,6 +26,7 @@ import BookmarkIcon from "@mui/icons-material/Bookmark";
 import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
 import { BACKEND_URL } from "../config";
 import { TMDBMovie } from "../types/tmdb";
+import { authErrorMessage } from "../util/auth";
 import CheckIcon from "@mui/icons-material/Check";
 import AddIcon from "@mui/icons-material/Add";
 
@@ -136,6 +137,8 @@ export function AddMovieDialog({ isOpen, onClose }: AddMovieDialogProps) {
             const metadata = movieMetadata[movie.id];
             const movieData = {
                 title: movie.title,
+                original_title: movie.original_title,
+                media_type: movie.media_type === "tv" ? "tv" : "movie",
                 description: movie.overview || "",
                 year: movie.release_date ? parseInt(movie.release_date.split("-")[0]) : 0,
                 image_path: movie.poster_path,
@@ -158,7 +161,9 @@ export function AddMovieDialog({ isOpen, onClose }: AddMovieDialogProps) {
             if (axios.isAxiosError(error) && error.response?.status === 409) {
                 setError("Dieser Film existiert bereits in Ihrer Sammlung");
             } else {
-                setError(error instanceof Error ? error.message : "Ein Fehler ist aufgetreten");
+                setError(
+                    authErrorMessage(error) ?? (error instanceof Error ? error.message : "Ein Fehler ist aufgetreten")
+                );
             }
         },
     });
@@ -248,7 +253,9 @@ export function AddMovieDialog({ isOpen, onClose }: AddMovieDialogProps) {
             if (axios.isAxiosError(error) && error.response?.status === 409) {
                 setError("Mindestens ein Film existiert bereits in Ihrer Sammlung");
             } else {
-                setError(error instanceof Error ? error.message : "Ein Fehler ist aufgetreten");
+                setError(
+                    authErrorMessage(error) ?? (error instanceof Error ? error.message : "Ein Fehler ist aufgetreten")
+                );
             }
         }
     };
@@ -2,15 +2,21 @@ import { AppBar, IconButton, Toolbar, Typography, useTheme } from "@mui/material
 import Brightness4Icon from "@mui/icons-material/Brightness4";
 import Brightness7Icon from "@mui/icons-material/Brightness7";
 import InventoryIcon from "@mui/icons-material/Inventory";
+import LoginIcon from "@mui/icons-material/Login";
+import LogoutIcon from "@mui/icons-material/Logout";
 import { useThemeContext } from "../context/themeContext";
+import { useAuthContext } from "../context/authContext";
 import { useState } from "react";
 import SbomDialog from "./SbomDialog";
+import { LoginDialog } from "./LoginDialog";
 import { VersionInfo } from "./VersionInfo";
 
 export const Header = () => {
     const theme = useTheme();
     const { toggleTheme } = useThemeContext();
+    const { user, logout } = useAuthContext();
     const [sbomOpen, setSbomOpen] = useState(false);
+    const [loginOpen, setLoginOpen] = useState(false);
 
     return (
         <>
@@ -39,9 +45,29 @@ export const Header = () => {
                     <IconButton onClick={toggleTheme} color='inherit' data-testid='theme-toggle-button'>
                         {theme.palette.mode === "dark" ? <Brightness7Icon /> : <Brightness4Icon />}
                     </IconButton>
+                    {user ? (
+                        <>
+                            <Typography variant='body2' sx={{ ml: 1 }} data-testid='current-user'>
+                                {user.username}
+                            </Typography>
+                            <IconButton onClick={logout} color='inherit' title='Abmelden' data-testid='logout-button'>
+                                <LogoutIcon />
+                            </IconButton>
+                        </>
+                    ) : (
+                        <IconButton
+                            onClick={() => setLoginOpen(true)}
+                            color='inherit'
+                            title='Anmelden'
+                            data-testid='login-button'
+                        >
+                            <LoginIcon />
+                        </IconButton>
+                    )}
                 </Toolbar>
             </AppBar>
             <SbomDialog open={sbomOpen} onClose={() => setSbomOpen(false)} />
+            <LoginDialog open={loginOpen} onClose={() => setLoginOpen(false)} />
         </>
     );
 };
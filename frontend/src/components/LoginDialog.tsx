@@ -0,0 +1,106 @@
+import { useEffect, useState } from "react";
+import {
+    Alert,
+    Box,
+    Button,
+    Dialog,
+    DialogActions,
+    DialogContent,
+    DialogTitle,
+    IconButton,
+    TextField,
+} from "@mui/material";
+import { LoadingButton } from "@mui/lab";
+import axios from "axios";
+import CloseIcon from "@mui/icons-material/Close";
+import { useAuthContext } from "../context/authContext";
+
+interface LoginDialogProps {
+    open: boolean;
+    onClose: () => void;
+}
+
+export function LoginDialog({ open, onClose }: LoginDialogProps) {
+    const { login } = useAuthContext();
+    const [username, setUsername] = useState("");
+    const [password, setPassword] = useState("");
+    const [error, setError] = useState<string | null>(null);
+    const [isSubmitting, setIsSubmitting] = useState(false);
+
+    useEffect(() => {
+        if (!open) {
+            // Eingaben beim Schließen verwerfen, damit das Passwort nicht im Formular stehen bleibt
+            setUsername("");
+            setPassword("");
+            setError(null);
+        }
+    }, [open]);
+
+    const handleSubmit = async (event: React.FormEvent) => {
+        event.preventDefault();
+        setError(null);
+        setIsSubmitting(true);
+        try {
+            await login(username, password);
+            onClose();
+        } catch (err) {
+            if (axios.isAxiosError(err) && err.response?.status === 401) {
+                setError("Benutzername oder Passwort ist falsch");
+            } else {
+                console.error("Fehler bei der Anmeldung:", err);
+                setError("Anmeldung fehlgeschlagen");
+            }
+        } finally {
+            setIsSubmitting(false);
+        }
+    };
+
+    return (
+        <Dialog open={open} onClose={onClose} maxWidth='xs' fullWidth>
+            <Box component='form' onSubmit={handleSubmit}>
+                <DialogTitle sx={{ display: "flex", justifyContent: "space-between", alignItems: "center" }}>
+                    Anmelden
+                    <IconButton aria-label='close' onClick={onClose} sx={{ color: "text.secondary" }}>
+                        <CloseIcon />
+                    </IconButton>
+                </DialogTitle>
+                <DialogContent>
+                    {error && (
+                        <Alert severity='error' sx={{ mb: 2 }}>
+                            {error}
+                        </Alert>
+                    )}
+                    <TextField
+                        label='Benutzername'
+                        value={username}
+                        onChange={(e) => setUsername(e.target.value)}
+                        autoComplete='username'
+                        autoFocus
+                        fullWidth
+                        margin='dense'
+                    />
+                    <TextField
+                        label='Passwort'
+                        type='password'
+                        value={password}
+                        onChange={(e) => setPassword(e.target.value)}
+                        autoComplete='current-password'
+                        fullWidth
+                        margin='dense'
+                    />
+                </DialogContent>
+                <DialogActions>
+                    <Button onClick={onClose}>Abbrechen</Button>
+                    <LoadingButton
+                        type='submit'
+                        variant='contained'
+                        loading={isSubmitting}
+                        disabled={!username.trim() || !password}
+                    >
+                        Anmelden
+                    </LoadingButton>
+                </DialogActions>
+            </Box>
+        </Dialog>
+    );
+}
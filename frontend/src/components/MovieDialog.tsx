@@ -30,6 +30,7 @@ import axios from "axios";
 import { useState, useEffect } from "react";
 import { Movie } from "../types";
 import { BACKEND_URL } from "../config";
+import { authErrorMessage } from "../util/auth";
 
 interface MovieDialogProps {
     open: boolean;
@@ -77,7 +78,7 @@ export const MovieDialog = ({ movie, open, onClose }: MovieDialogProps) => {
         },
         onError: (error) => {
             console.error("Error deleting movie:", error);
-            setError("Löschen fehlgeschlagen");
+            setError(authErrorMessage(error) ?? "Löschen fehlgeschlagen");
         },
     });
 
@@ -127,7 +128,7 @@ export const MovieDialog = ({ movie, open, onClose }: MovieDialogProps) => {
         },
         onError: (error) => {
             console.error("Error saving movie:", error);
-            setError("Speichern fehlgeschlagen");
+            setError(authErrorMessage(error) ?? "Speichern fehlgeschlagen");
         },
     });
 
@@ -0,0 +1,75 @@
+import { ReactNode, createContext, useCallback, useContext, useEffect, useState } from "react";
+import axios from "axios";
+import { useQueryClient } from "@tanstack/react-query";
+import { BACKEND_URL } from "../config";
+import { User } from "../types";
+import { onSessionExpired, refreshSession } from "../util/auth";
+
+// Context types
+interface AuthContextType {
+    user: User | null;
+    isLoading: boolean;
+    login: (username: string, password: string) => Promise<void>;
+    logout: () => Promise<void>;
+}
+
+// Default context values
+const defaultAuthContext: AuthContextType = {
+    user: null,
+    isLoading: false,
+    login: async () => {},
+    logout: async () => {},
+};
+
+// Create context
+const AuthContext = createContext<AuthContextType>(defaultAuthContext);
+
+// Custom hook to use the auth context
+export const useAuthContext = () => useContext(AuthContext);
+
+// Provider component
+export const AuthProvider = ({ children }: { children: ReactNode }) => {
+    const queryClient = useQueryClient();
+    const [user, setUser] = useState<User | null>(null);
+    const [isLoading, setIsLoading] = useState<boolean>(true);
+
+    // Nach einem Wechsel des Benutzers zeigt die Filmliste eine andere Sammlung
+    const switchUser = useCallback(
+        (next: User | null) => {
+            setUser(next);
+            queryClient.invalidateQueries({ queryKey: ["movies"] });
+        },
+        [queryClient]
+    );
+
+    useEffect(() => {
+        // Eine bestehende Sitzung über den Refresh-Token-Cookie wiederherstellen
+        refreshSession()
+            .then((restored) => switchUser(restored))
+            .catch(() => setUser(null))
+            .finally(() => setIsLoading(false));
+
+        return onSessionExpired(() => switchUser(null));
+    }, [switchUser]);
+
+    const login = async (username: string, password: string) => {
+        const response = await axios.post<User>(
+            `${BACKEND_URL}/auth/login`,
+            { username, password },
+            { withCredentials: true }
+        );
+        switchUser(response.data);
+    };
+
+    const logout = async () => {
+        try {
+            await axios.post(`${BACKEND_URL}/auth/logout`, undefined, { withCredentials: true });
+        } catch (err) {
+            console.error("Fehler beim Abmelden:", err);
+        }
+        // Lokal abmelden, auch wenn das Backend nicht erreichbar war; die Cookies laufen dann regulär ab
+        switchUser(null);
+    };
+
+    return <AuthContext.Provider value={{ user, isLoading, login, logout }}>{children}</AuthContext.Provider>;
+};
@@ -4,6 +4,9 @@ import { QueryClient, QueryClientProvider } from "@tanstack/react-query";
 import "./index.css";
 import App from "./App.tsx";
 import { VersionProvider } from "./context/versionContext.tsx";
+import { setupAuth } from "./util/auth.ts";
+
+setupAuth();
 
 const queryClient = new QueryClient();
 
@@ -43,3 +43,13 @@ export interface UserPreferences {
     theme: "light" | "dark";
     language: string;
 }
+
+export type UserRole = "viewer" | "editor" | "admin";
+
+export interface User {
+    id: number;
+    username: string;
+    role: UserRole;
+    created_at?: string;
+    updated_at?: string;
+}
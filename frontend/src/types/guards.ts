@@ -103,6 +103,7 @@ export function isTMDBMovie(obj: unknown): obj is TMDBMovie {
     if (
         (movie.vote_average !== undefined && typeof movie.vote_average !== "number") ||
         (movie.media_type !== undefined && typeof movie.media_type !== "string") ||
+        (movie.original_title !== undefined && typeof movie.original_title !== "string") ||
         (movie.overview !== undefined && typeof movie.overview !== "string")
     ) {
         return false;
@@ -1,6 +1,7 @@
 export interface TMDBMovie {
     id: number;
     title: string;
+    original_title?: string;
     poster_path: string;
     release_date: string;
     vote_average?: number;
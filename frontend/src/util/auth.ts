@@ -0,0 +1,67 @@
+import axios, { AxiosError, AxiosInstance, InternalAxiosRequestConfig } from "axios";
+import { BACKEND_URL } from "../config";
+import { User } from "../types";
+
+type RetryableConfig = InternalAxiosRequestConfig & { _authRetried?: boolean };
+
+const sessionExpiredListeners = new Set<() => void>();
+
+let refreshing: Promise<User> | null = null;
+
+// Erneuert die Sitzung über den Refresh-Token-Cookie. Gleichzeitige Aufrufe teilen sich eine Anfrage,
+// da jeder Refresh-Token nur einmal gültig ist und eine Wiederverwendung die ganze Sitzung beendet.
+export const refreshSession = (client: AxiosInstance = axios) => {
+    if (!refreshing) {
+        refreshing = client
+            .post<User>(`${BACKEND_URL}/auth/refresh`, undefined, { withCredentials: true })
+            .then((response) => response.data)
+            .finally(() => {
+                refreshing = null;
+            });
+    }
+    return refreshing;
+};
+
+// Übersetzt fehlende Anmeldung oder Berechtigung in eine Meldung für den Benutzer
+export const authErrorMessage = (error: unknown): string | null => {
+    if (!axios.isAxiosError(error)) {
+        return null;
+    }
+    if (error.response?.status === 401) {
+        return "Bitte melden Sie sich an";
+    }
+    if (error.response?.status === 403) {
+        return "Dafür fehlt Ihnen die Berechtigung";
+    }
+    return null;
+};
+
+// Meldet, wenn die Sitzung nicht mehr erneuert werden kann und der Benutzer sich neu anmelden muss
+export const onSessionExpired = (listener: () => void) => {
+    sessionExpiredListeners.add(listener);
+    return () => {
+        sessionExpiredListeners.delete(listener);
+    };
+};
+
+// Access- und Refresh-Token liegen als HttpOnly-Cookies vor; der Browser sendet sie nur mit withCredentials mit.
+// Läuft der Access-Token ab, erneuert der Interceptor die Sitzung einmalig und wiederholt die Anfrage.
+export const setupAuth = (client: AxiosInstance = axios) => {
+    client.defaults.withCredentials = true;
+
+    client.interceptors.response.use(undefined, async (error: AxiosError) => {
+        const config = error.config as RetryableConfig | undefined;
+        if (error.response?.status !== 401 || !config || config._authRetried || config.url?.includes("/auth/")) {
+            return Promise.reject(error);
+        }
+        config._authRetried = true;
+
+        try {
+            await refreshSession(client);
+        } catch {
+            sessionExpiredListeners.forEach((listener) => listener());
+            return Promise.reject(error);
+        }
+        return client(config);
+    });
+};